@@ -0,0 +1,168 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultHistorySize Сколько последних изменений хранит Proxy по умолчанию
+const DefaultHistorySize = 100
+
+// Ошибки навигации по истории
+var (
+	// ErrNothingToUndo История пуста
+	ErrNothingToUndo = errors.New("нечего отменять")
+	// ErrNothingToRedo Нет отменённых изменений
+	ErrNothingToRedo = errors.New("нечего повторять")
+	// ErrHistoryRange Номер записи вне истории
+	ErrHistoryRange = errors.New("номер записи вне истории")
+)
+
+// ChangeRecord Запись об изменении
+type ChangeRecord struct {
+	Field    string
+	OldValue interface{}
+	NewValue interface{}
+	Time     time.Time
+
+	// created Изменение добавило ключ map: отмена удаляет его
+	created bool
+}
+
+// SetHistorySize Задать, сколько последних изменений хранить (0 и меньше — без ограничения).
+// Лишние старые записи удаляются сразу
+func (p *ReactiveProxy) SetHistorySize(size int) {
+	p = p.raw()
+	p.historySize = size
+	p.history = trimHistory(p.history, size)
+}
+
+// record Записать изменение в историю. Новое изменение делает отменённые недоступными для Redo
+func (p *ReactiveProxy) record(fieldName string, oldValue, newValue interface{}, created bool) {
+	if p.replaying {
+		return
+	}
+
+	p.history = append(p.history, ChangeRecord{
+		Field:    fieldName,
+		OldValue: oldValue,
+		NewValue: newValue,
+		Time:     p.clock.Now(),
+		created:  created,
+	})
+	p.history = trimHistory(p.history, p.historySize)
+	p.redo = p.redo[:0]
+}
+
+// trimHistory Оставить size последних записей (FIFO)
+func trimHistory(history []ChangeRecord, size int) []ChangeRecord {
+	if size <= 0 || len(history) <= size {
+		return history
+	}
+	trimmed := make([]ChangeRecord, size)
+	copy(trimmed, history[len(history)-size:])
+	return trimmed
+}
+
+// GetHistory Получить историю изменений
+func (p *ReactiveProxy) GetHistory() []ChangeRecord {
+	return p.raw().history
+}
+
+// ClearHistory Очистить историю и отменённые изменения
+func (p *ReactiveProxy) ClearHistory() {
+	p = p.raw()
+	p.history = make([]ChangeRecord, 0)
+	p.redo = make([]ChangeRecord, 0)
+}
+
+// CanUndo Есть ли изменение для отмены
+func (p *ReactiveProxy) CanUndo() bool {
+	return len(p.raw().history) > 0
+}
+
+// CanRedo Есть ли отменённое изменение для повтора
+func (p *ReactiveProxy) CanRedo() bool {
+	return len(p.raw().redo) > 0
+}
+
+// Undo Отменить последнее изменение. Старое значение записывается через Set,
+// поэтому наблюдатели и эффекты срабатывают как при обычном изменении
+func (p *ReactiveProxy) Undo() error {
+	root := p.raw()
+	if len(root.history) == 0 {
+		return ErrNothingToUndo
+	}
+
+	last := root.history[len(root.history)-1]
+	if err := p.replay(last, true); err != nil {
+		return err
+	}
+
+	root.history = root.history[:len(root.history)-1]
+	root.redo = append(root.redo, last)
+	return nil
+}
+
+// Redo Повторить последнее отменённое изменение
+func (p *ReactiveProxy) Redo() error {
+	root := p.raw()
+	if len(root.redo) == 0 {
+		return ErrNothingToRedo
+	}
+
+	next := root.redo[len(root.redo)-1]
+	if err := p.replay(next, false); err != nil {
+		return err
+	}
+
+	root.redo = root.redo[:len(root.redo)-1]
+	next.Time = root.clock.Now()
+	root.history = append(root.history, next)
+	return nil
+}
+
+// RevertTo Вернуть состояние к моменту после n-й записи истории (0 — до первой).
+// Отменённые записи доступны для Redo
+func (p *ReactiveProxy) RevertTo(n int) error {
+	root := p.raw()
+	if n < 0 || n > len(root.history) {
+		return fmt.Errorf("%w: %d из %d", ErrHistoryRange, n, len(root.history))
+	}
+
+	for len(root.history) > n {
+		if err := p.Undo(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replay Применить запись вперёд или назад, не добавляя её в историю
+func (p *ReactiveProxy) replay(change ChangeRecord, undo bool) error {
+	root := p.raw()
+	root.replaying = true
+	defer func() {
+		root.replaying = false
+	}()
+
+	if !undo {
+		return p.set(change.Field, change.NewValue, false)
+	}
+	if !change.created {
+		return p.set(change.Field, change.OldValue, false)
+	}
+
+	if p.readonly {
+		return readonlyError(change.Field)
+	}
+	path, err := parsePath(change.Field)
+	if err != nil {
+		return err
+	}
+	p.runtime.Batch(func() {
+		err = p.deletePath(path)
+	})
+	return err
+}
@@ -0,0 +1,117 @@
+package proxy
+
+import (
+	"Guess/internal/reactivity"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestHistorySize проверяем настраиваемую длину истории и время записей
+func TestHistorySize(t *testing.T) {
+	person := &TestPerson{Name: "Лена", Age: 22}
+	proxy := NewReactiveProxy(person)
+	clock := reactivity.NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	proxy.SetClock(clock)
+	proxy.SetHistorySize(2)
+
+	proxy.Set("Age", 23)
+	clock.Advance(time.Minute)
+	proxy.Set("Age", 24)
+	clock.Advance(time.Minute)
+	proxy.Set("Age", 25)
+
+	history := proxy.GetHistory()
+	if len(history) != 2 {
+		t.Fatalf("Ожидали 2 записи, получили %d", len(history))
+	}
+	if history[0].OldValue != 23 || history[1].NewValue != 25 {
+		t.Errorf("Должны остаться последние записи, получили %+v", history)
+	}
+	if !history[1].Time.Equal(time.Date(2024, 1, 1, 12, 2, 0, 0, time.UTC)) {
+		t.Errorf("Время записи должно браться из часов Proxy, получили %v", history[1].Time)
+	}
+}
+
+// TestUndoRedo проверяем отмену и повтор через Set с вызовом наблюдателей
+func TestUndoRedo(t *testing.T) {
+	person := &TestPerson{Name: "Лена", Age: 22}
+	proxy := NewReactiveProxy(person)
+
+	var watched []interface{}
+	proxy.Watch("Name", "Set", func(fieldName string, oldValue, newValue interface{}) {
+		watched = append(watched, newValue)
+	})
+
+	proxy.Set("Name", "Оля")
+	proxy.Set("Age", 25)
+
+	if err := proxy.Undo(); err != nil {
+		t.Fatalf("Неожиданная ошибка %v", err)
+	}
+	if err := proxy.Undo(); err != nil {
+		t.Fatalf("Неожиданная ошибка %v", err)
+	}
+	if person.Name != "Лена" || person.Age != 22 {
+		t.Errorf("Undo должен вернуть исходные значения, получили %+v", person)
+	}
+	if err := proxy.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Ожидали ErrNothingToUndo, получили %v", err)
+	}
+
+	if err := proxy.Redo(); err != nil {
+		t.Fatalf("Неожиданная ошибка %v", err)
+	}
+	if person.Name != "Оля" || !proxy.CanRedo() {
+		t.Errorf("Redo должен повторить первое изменение, получили %+v", person)
+	}
+
+	// Новое изменение сбрасывает отменённые
+	proxy.Set("Name", "Света")
+	if proxy.CanRedo() {
+		t.Error("После нового изменения Redo недоступен")
+	}
+	if len(proxy.GetHistory()) != 2 {
+		t.Errorf("Undo и Redo не должны добавлять записи, получили %d", len(proxy.GetHistory()))
+	}
+
+	want := []interface{}{"Оля", "Лена", "Оля", "Света"}
+	if len(watched) != len(want) {
+		t.Fatalf("Ожидали вызовы наблюдателя %v, получили %v", want, watched)
+	}
+	for i := range want {
+		if watched[i] != want[i] {
+			t.Errorf("Ожидали вызовы наблюдателя %v, получили %v", want, watched)
+			break
+		}
+	}
+}
+
+// TestRevertTo проверяем возврат к записи истории и отмену добавления ключа map
+func TestRevertTo(t *testing.T) {
+	report := newTestReport()
+	proxy := NewReactiveProxy(report)
+
+	proxy.Set("Memory.Heap", 11)
+	proxy.Set("Labels[region]", "eu")
+	proxy.Set("Memory.Heap", 12)
+
+	if err := proxy.RevertTo(1); err != nil {
+		t.Fatalf("Неожиданная ошибка %v", err)
+	}
+	if report.Memory.Heap != 11 {
+		t.Errorf("Ожидали Heap 11, получили %d", report.Memory.Heap)
+	}
+	if _, exists := report.Labels["region"]; exists {
+		t.Error("Отмена добавления ключа должна удалить его")
+	}
+
+	if err := proxy.RevertTo(5); !errors.Is(err, ErrHistoryRange) {
+		t.Errorf("Ожидали ErrHistoryRange, получили %v", err)
+	}
+
+	proxy.Redo()
+	if report.Labels["region"] != "eu" {
+		t.Errorf("Redo должен вернуть ключ, получили %v", report.Labels)
+	}
+}
@@ -0,0 +1,24 @@
+package proxy
+
+// SetInterceptor Обработчик перед записью значения. Получает путь, текущее и новое значение;
+// возвращает значение для записи (можно заменить или привести) или ошибку, отменяющую Set
+type SetInterceptor func(fieldName string, oldValue, newValue interface{}) (interface{}, error)
+
+// Intercept Добавить обработчик в конец цепочки. Обработчики вызываются по порядку
+// при каждом Set (в том числе из Undo, Redo и ApplyPatch), каждый получает результат предыдущего.
+// Проверки из тегов validate выполняются после всей цепочки
+func (p *ReactiveProxy) Intercept(interceptor SetInterceptor) {
+	root := p.raw()
+	root.interceptors = append(root.interceptors, interceptor)
+}
+
+// intercept Пропустить значение через цепочку обработчиков
+func (p *ReactiveProxy) intercept(fieldName string, oldValue, newValue interface{}) (interface{}, error) {
+	var err error
+	for _, interceptor := range p.raw().interceptors {
+		if newValue, err = interceptor(fieldName, oldValue, newValue); err != nil {
+			return nil, err
+		}
+	}
+	return newValue, nil
+}
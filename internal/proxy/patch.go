@@ -0,0 +1,512 @@
+package proxy
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Snapshot Снимок состояния: структуры и map превращаются в map[string]interface{},
+// срезы и массивы — в []interface{}, остальные значения копируются как есть.
+// Ключи структур — имена полей Go, поэтому пути снимка совпадают с путями Proxy
+type Snapshot map[string]interface{}
+
+// PatchOperation Операция JSON Patch (RFC 6902)
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Patch Последовательность операций JSON Patch
+type Patch []PatchOperation
+
+// Ошибки применения Patch
+var (
+	// ErrPatchOperation Неизвестная или неприменимая операция
+	ErrPatchOperation = errors.New("некорректная операция patch")
+	// ErrPatchTest Операция test не совпала с текущим значением
+	ErrPatchTest = errors.New("проверка test не прошла")
+)
+
+// MarshalJSON Value пишется всегда для add, replace и test, даже если это null
+func (op PatchOperation) MarshalJSON() ([]byte, error) {
+	type plain PatchOperation
+	if op.Op != "add" && op.Op != "replace" && op.Op != "test" {
+		return json.Marshal(plain(op))
+	}
+
+	return json.Marshal(struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}{op.Op, op.Path, op.Value})
+}
+
+// Snapshot Снять состояние объекта. Внутри эффекта подписывает его на все реактивные поля верхнего уровня
+func (p *ReactiveProxy) Snapshot() Snapshot {
+	value := reflect.ValueOf(p.target)
+	for value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+
+	info := typeInfoFor(value.Type())
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if fi := info.field(field.Name); fi != nil && !fi.ignore {
+			p.runtime.Track(p.target, field.Name)
+		}
+	}
+
+	snapshot, _ := snapshotValue(value).(map[string]interface{})
+	return snapshot
+}
+
+// snapshotValue Копия значения в виде дерева map/срезов
+func snapshotValue(value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+
+	// Значения со своим представлением (time.Time и т.п.) не разбираем
+	if value.CanInterface() {
+		switch value.Interface().(type) {
+		case json.Marshaler, encoding.TextMarshaler:
+			return value.Interface()
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return snapshotValue(value.Elem())
+
+	case reflect.Struct:
+		result := make(map[string]interface{}, value.NumField())
+		for i := 0; i < value.NumField(); i++ {
+			if field := value.Type().Field(i); field.IsExported() {
+				result[field.Name] = snapshotValue(value.Field(i))
+			}
+		}
+		return result
+
+	case reflect.Map:
+		if value.IsNil() {
+			return nil
+		}
+		result := make(map[string]interface{}, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			result[fmt.Sprint(iter.Key().Interface())] = snapshotValue(iter.Value())
+		}
+		return result
+
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil
+		}
+		result := make([]interface{}, value.Len())
+		for i := range result {
+			result[i] = snapshotValue(value.Index(i))
+		}
+		return result
+	}
+
+	return value.Interface()
+}
+
+// Diff Операции JSON Patch, превращающие снимок a в снимок b. Порядок операций детерминирован
+func Diff(a, b Snapshot) Patch {
+	patch := make(Patch, 0)
+	return diffValues(patch, "", map[string]interface{}(a), map[string]interface{}(b))
+}
+
+func diffValues(patch Patch, pointer string, a, b interface{}) Patch {
+	switch aValue := a.(type) {
+	case map[string]interface{}:
+		if bValue, ok := b.(map[string]interface{}); ok {
+			return diffMaps(patch, pointer, aValue, bValue)
+		}
+	case []interface{}:
+		if bValue, ok := b.([]interface{}); ok {
+			return diffSlices(patch, pointer, aValue, bValue)
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		patch = append(patch, PatchOperation{Op: "replace", Path: pointer, Value: b})
+	}
+	return patch
+}
+
+func diffMaps(patch Patch, pointer string, a, b map[string]interface{}) Patch {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, exists := a[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		child := pointer + "/" + escapePointer(key)
+		aValue, inA := a[key]
+		bValue, inB := b[key]
+
+		switch {
+		case !inB:
+			patch = append(patch, PatchOperation{Op: "remove", Path: child})
+		case !inA:
+			patch = append(patch, PatchOperation{Op: "add", Path: child, Value: bValue})
+		default:
+			patch = diffValues(patch, child, aValue, bValue)
+		}
+	}
+	return patch
+}
+
+// diffSlices Общие индексы сравниваются поэлементно, лишние удаляются с конца, новые добавляются в конец
+func diffSlices(patch Patch, pointer string, a, b []interface{}) Patch {
+	common := min(len(a), len(b))
+	for i := 0; i < common; i++ {
+		patch = diffValues(patch, pointer+"/"+strconv.Itoa(i), a[i], b[i])
+	}
+	for i := len(a) - 1; i >= common; i-- {
+		patch = append(patch, PatchOperation{Op: "remove", Path: pointer + "/" + strconv.Itoa(i)})
+	}
+	for i := common; i < len(b); i++ {
+		patch = append(patch, PatchOperation{Op: "add", Path: pointer + "/-", Value: b[i]})
+	}
+	return patch
+}
+
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func unescapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+// ApplyPatch Применить JSON Patch. Каждое изменение проходит через Set,
+// поэтому наблюдатели, история и эффекты срабатывают как обычно; эффекты перезапускаются
+// один раз после всего Patch. Patch атомарен: при ошибке любой операции уже применённые
+// откатываются, а история возвращается к состоянию до Patch
+func (p *ReactiveProxy) ApplyPatch(patch Patch) error {
+	if p.readonly {
+		return readonlyError("patch")
+	}
+
+	root := p.raw()
+	history := append([]ChangeRecord(nil), root.history...)
+	redo := append([]ChangeRecord(nil), root.redo...)
+
+	// Вложенный Patch (например, из наблюдателя) пишет в свой журнал,
+	// а при успехе передаёт его внешнему, чтобы тот мог откатить и эти изменения
+	outer := root.journal
+	journal := make([]ChangeRecord, 0, len(patch))
+	root.journal = &journal
+
+	var err error
+	p.runtime.Batch(func() {
+		defer func() {
+			root.journal = outer
+		}()
+
+		for i, op := range patch {
+			if err = p.applyOperation(op); err != nil {
+				err = fmt.Errorf("операция %d (%s %s): %w", i, op.Op, op.Path, err)
+				p.rollback(journal)
+				root.history = history
+				root.redo = redo
+				return
+			}
+		}
+		if outer != nil {
+			*outer = append(*outer, journal...)
+		}
+	})
+	return err
+}
+
+// journalChange Запомнить изменение для отката, если идёт ApplyPatch.
+// В журнал попадают и поля вне истории (reactive:"-", history:"-")
+func (p *ReactiveProxy) journalChange(fieldName string, oldValue, newValue interface{}, created bool) {
+	if p.journal == nil || p.replaying {
+		return
+	}
+	*p.journal = append(*p.journal, ChangeRecord{
+		Field:    fieldName,
+		OldValue: oldValue,
+		NewValue: newValue,
+		created:  created,
+	})
+}
+
+// rollback Вернуть изменения журнала в обратном порядке. Старые значения записываются
+// напрямую, минуя перехватчики и проверки: они уже были в объекте. Наблюдатели и эффекты
+// срабатывают, чтобы увидеть возврат, история не пишется
+func (p *ReactiveProxy) rollback(journal []ChangeRecord) {
+	root := p.raw()
+	root.replaying = true
+	defer func() {
+		root.replaying = false
+	}()
+
+	for i := len(journal) - 1; i >= 0; i-- {
+		change := journal[i]
+		path, flags, err := p.parse(change.Field)
+		if err != nil {
+			continue
+		}
+		if change.created {
+			_ = p.deletePath(path)
+			continue
+		}
+
+		slot, err := slotForPath(reflect.ValueOf(p.target), path)
+		if err != nil {
+			continue
+		}
+		oldValue := reflect.Zero(slot.Type())
+		if change.OldValue != nil {
+			oldValue = reflect.ValueOf(change.OldValue)
+		}
+		slot.Set(oldValue)
+
+		if flags.ignore {
+			continue
+		}
+		p.notify(change.Field, "Set", change.NewValue, change.OldValue)
+		p.trigger(path)
+	}
+}
+
+func (p *ReactiveProxy) applyOperation(op PatchOperation) error {
+	switch op.Op {
+	case "add":
+		return p.patchAdd(op.Path, op.Value)
+	case "replace":
+		return p.patchReplace(op.Path, op.Value)
+	case "remove":
+		return p.patchRemove(op.Path)
+	case "move", "copy":
+		value, err := p.patchGet(op.From)
+		if err != nil {
+			return err
+		}
+		if op.Op == "move" {
+			if err := p.patchRemove(op.From); err != nil {
+				return err
+			}
+		}
+		return p.patchAdd(op.Path, value)
+	case "test":
+		current, err := p.patchGet(op.Path)
+		if err != nil {
+			return err
+		}
+		_, leafType, err := p.pointerPath(op.Path)
+		if err != nil {
+			return err
+		}
+		expected, err := decodeValue(op.Value, leafType)
+		if err != nil {
+			return err
+		}
+		// Сравниваем в виде снимка: null совпадает и с nil-срезом, и с nil-указателем
+		if !reflect.DeepEqual(snapshotValue(reflect.ValueOf(current)), snapshotValue(reflect.ValueOf(expected))) {
+			return fmt.Errorf("%w: ожидали %v, сейчас %v", ErrPatchTest, expected, current)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrPatchOperation, op.Op)
+}
+
+// pointerPath Перевести JSON Pointer в путь Proxy, сверяясь с типами: /Rows/3/Name -> Rows[3].Name.
+// Возвращает и тип значения по пути
+func (p *ReactiveProxy) pointerPath(pointer string) (fieldPath, reflect.Type, error) {
+	if pointer == "" || pointer[0] != '/' {
+		return nil, nil, fmt.Errorf("%w: %q: ожидается путь от корня вида /Field", ErrInvalidPath, pointer)
+	}
+
+	current := reflect.TypeOf(p.target)
+	path := make(fieldPath, 0)
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = unescapePointer(token)
+		for current.Kind() == reflect.Pointer {
+			current = current.Elem()
+		}
+
+		switch current.Kind() {
+		case reflect.Struct:
+			field, exists := current.FieldByName(token)
+			if !exists {
+				return nil, nil, fmt.Errorf("%w: %s", ErrUnknownField, pointer)
+			}
+			path = append(path, pathSegment{field: token})
+			current = field.Type
+		case reflect.Slice, reflect.Array, reflect.Map:
+			path = append(path, pathSegment{index: token, isIndex: true})
+			current = current.Elem()
+		default:
+			return nil, nil, fmt.Errorf("%w: %s: %s не содержит %q", ErrInvalidPath, pointer, current, token)
+		}
+	}
+	return path, current, nil
+}
+
+func (p *ReactiveProxy) patchGet(pointer string) (interface{}, error) {
+	path, _, err := p.pointerPath(pointer)
+	if err != nil {
+		return nil, err
+	}
+	value, err := lookupPath(reflect.ValueOf(p.target), path)
+	if err != nil {
+		return nil, err
+	}
+	return value.Interface(), nil
+}
+
+func (p *ReactiveProxy) patchReplace(pointer string, value interface{}) error {
+	path, leafType, err := p.pointerPath(pointer)
+	if err != nil {
+		return err
+	}
+	decoded, err := decodeValue(value, leafType)
+	if err != nil {
+		return err
+	}
+	return p.set(path.String(), decoded, false)
+}
+
+// patchAdd В срез add вставляет элемент (индекс - — в конец), в map и структуру — записывает значение
+func (p *ReactiveProxy) patchAdd(pointer string, value interface{}) error {
+	parent, index, isElem, err := p.sliceParent(pointer)
+	if err != nil {
+		return err
+	}
+	if !isElem {
+		return p.patchReplace(pointer, value)
+	}
+
+	slice, err := lookupPath(reflect.ValueOf(p.target), parent)
+	if err != nil {
+		return err
+	}
+	if index == "-" {
+		index = strconv.Itoa(slice.Len())
+	}
+	i, convErr := strconv.Atoi(index)
+	if convErr != nil || i < 0 || i > slice.Len() {
+		return fmt.Errorf("%w: %s: индекс вне диапазона 0..%d", ErrInvalidPath, pointer, slice.Len())
+	}
+
+	elem, err := decodeValue(value, slice.Type().Elem())
+	if err != nil {
+		return err
+	}
+	elemValue := reflect.New(slice.Type().Elem()).Elem()
+	if elem != nil {
+		elemValue.Set(reflect.ValueOf(elem))
+	}
+
+	result := reflect.MakeSlice(slice.Type(), 0, slice.Len()+1)
+	result = reflect.AppendSlice(result, slice.Slice(0, i))
+	result = reflect.Append(result, elemValue)
+	result = reflect.AppendSlice(result, slice.Slice(i, slice.Len()))
+	return p.set(parent.String(), result.Interface(), false)
+}
+
+// patchRemove Из среза элемент удаляется со сдвигом, из map — ключ, поле структуры обнуляется
+func (p *ReactiveProxy) patchRemove(pointer string) error {
+	parent, index, isElem, err := p.sliceParent(pointer)
+	if err != nil {
+		return err
+	}
+
+	if isElem {
+		slice, err := lookupPath(reflect.ValueOf(p.target), parent)
+		if err != nil {
+			return err
+		}
+		i, convErr := strconv.Atoi(index)
+		if convErr != nil || i < 0 || i >= slice.Len() {
+			return fmt.Errorf("%w: %s: индекс вне диапазона 0..%d", ErrInvalidPath, pointer, slice.Len()-1)
+		}
+
+		result := reflect.MakeSlice(slice.Type(), 0, slice.Len()-1)
+		result = reflect.AppendSlice(result, slice.Slice(0, i))
+		result = reflect.AppendSlice(result, slice.Slice(i+1, slice.Len()))
+		return p.set(parent.String(), result.Interface(), false)
+	}
+
+	path, leafType, err := p.pointerPath(pointer)
+	if err != nil {
+		return err
+	}
+
+	slot, err := slotForPath(reflect.ValueOf(p.target), path)
+	if err != nil {
+		return err
+	}
+	if slot.m.IsValid() {
+		if !slot.Exists() {
+			return fmt.Errorf("%w: %s: нет ключа", ErrUnknownField, pointer)
+		}
+		return p.deletePath(path)
+	}
+	return p.set(path.String(), reflect.Zero(leafType).Interface(), false)
+}
+
+// sliceParent Разобрать путь к элементу среза: путь к самому срезу и индекс
+func (p *ReactiveProxy) sliceParent(pointer string) (fieldPath, string, bool, error) {
+	last := strings.LastIndexByte(pointer, '/')
+	if last <= 0 {
+		return nil, "", false, nil
+	}
+
+	parent, parentType, err := p.pointerPath(pointer[:last])
+	if err != nil {
+		return nil, "", false, err
+	}
+	for parentType.Kind() == reflect.Pointer {
+		parentType = parentType.Elem()
+	}
+	if parentType.Kind() != reflect.Slice {
+		return nil, "", false, nil
+	}
+	return parent, unescapePointer(pointer[last+1:]), true, nil
+}
+
+// decodeValue Привести значение из Patch к типу поля. Значения из Diff подходят как есть,
+// значения из JSON (float64, map[string]interface{}) разбираются через encoding/json
+func decodeValue(value interface{}, to reflect.Type) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if reflect.TypeOf(value).AssignableTo(to) {
+		return value, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTypeMismatch, err)
+	}
+	decoded := reflect.New(to)
+	if err := json.Unmarshal(data, decoded.Interface()); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTypeMismatch, err)
+	}
+	return decoded.Elem().Interface(), nil
+}
@@ -0,0 +1,167 @@
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestSnapshotDiff проверяем снимок и операции RFC 6902 между двумя снимками
+func TestSnapshotDiff(t *testing.T) {
+	proxy := NewReactiveProxy(newTestReport())
+	before := proxy.Snapshot()
+
+	proxy.Set("Memory.Heap", 11)
+	proxy.Set("Labels[region]", "eu")
+	proxy.Set("Rows", []testRow{{Name: "a"}})
+
+	patch := Diff(before, proxy.Snapshot())
+	want := Patch{
+		{Op: "add", Path: "/Labels/region", Value: "eu"},
+		{Op: "replace", Path: "/Memory/Heap", Value: 11},
+		{Op: "remove", Path: "/Rows/1"},
+	}
+	if !reflect.DeepEqual(patch, want) {
+		t.Errorf("Ожидали %v, получили %v", want, patch)
+	}
+
+	if len(Diff(before, before)) != 0 {
+		t.Error("Одинаковые снимки не должны давать операций")
+	}
+}
+
+// TestApplyPatch проверяем, что Patch из Diff переносит изменения на другой объект через Set
+func TestApplyPatch(t *testing.T) {
+	source := NewReactiveProxy(newTestReport())
+	before := source.Snapshot()
+
+	source.Set("Memory.Heap", 11)
+	source.Set("Labels[region]", "eu")
+	source.Set("Rows", []testRow{{Name: "x"}, {Name: "b"}, {Name: "c"}})
+	delete(source.Original().(*testReport).Labels, "env")
+	patch := Diff(before, source.Snapshot())
+
+	replica := newTestReport()
+	proxy := NewReactiveProxy(replica)
+	var watched []string
+	proxy.Watch("Memory.Heap", "Set", func(fieldName string, oldValue, newValue interface{}) {
+		watched = append(watched, fieldName)
+	})
+
+	if err := proxy.ApplyPatch(patch); err != nil {
+		t.Fatalf("Неожиданная ошибка %v", err)
+	}
+	if !reflect.DeepEqual(replica, source.Original()) {
+		t.Errorf("Ожидали %+v, получили %+v", source.Original(), replica)
+	}
+	if len(watched) != 1 {
+		t.Errorf("Patch должен вызывать наблюдателей, вызовов: %d", len(watched))
+	}
+}
+
+// TestApplyPatchJSON проверяем Patch, пришедший в JSON из другого процесса
+func TestApplyPatchJSON(t *testing.T) {
+	report := newTestReport()
+	proxy := NewReactiveProxy(report)
+
+	data := []byte(`[
+		{"op": "test", "path": "/Memory/Heap", "value": 10},
+		{"op": "replace", "path": "/Memory", "value": {"Heap": 64, "Stack": 8}},
+		{"op": "add", "path": "/Rows/0", "value": {"Name": "first"}},
+		{"op": "copy", "from": "/Labels/env", "path": "/Labels/stage"},
+		{"op": "move", "from": "/Servers/db", "path": "/Servers/main"}
+	]`)
+	var patch Patch
+	if err := json.Unmarshal(data, &patch); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := proxy.ApplyPatch(patch); err != nil {
+		t.Fatalf("Неожиданная ошибка %v", err)
+	}
+	if report.Memory.Heap != 64 || report.Memory.Stack != 8 {
+		t.Errorf("replace не применился: %+v", report.Memory)
+	}
+	if len(report.Rows) != 3 || report.Rows[0].Name != "first" {
+		t.Errorf("add должен вставлять элемент: %+v", report.Rows)
+	}
+	if report.Labels["stage"] != "dev" {
+		t.Errorf("copy не применился: %v", report.Labels)
+	}
+	if _, exists := report.Servers["db"]; exists || report.Servers["main"].Name != "postgres" {
+		t.Errorf("move не применился: %v", report.Servers)
+	}
+
+	failed := Patch{{Op: "test", Path: "/Memory/Heap", Value: 1}}
+	if err := proxy.ApplyPatch(failed); !errors.Is(err, ErrPatchTest) {
+		t.Errorf("Ожидали ErrPatchTest, получили %v", err)
+	}
+	if err := proxy.ApplyPatch(Patch{{Op: "swap", Path: "/Memory"}}); !errors.Is(err, ErrPatchOperation) {
+		t.Errorf("Ожидали ErrPatchOperation, получили %v", err)
+	}
+}
+
+// TestApplyPatchRollback проверяем, что Patch с ошибкой не оставляет изменений ни в объекте, ни в истории
+func TestApplyPatchRollback(t *testing.T) {
+	report := newTestReport()
+	proxy := NewReactiveProxy(report)
+	proxy.Set("Memory.Stack", 4)
+
+	patch := Patch{
+		{Op: "replace", Path: "/Memory/Heap", Value: 64},
+		{Op: "add", Path: "/Labels/region", Value: "eu"},
+		{Op: "remove", Path: "/Labels/env"},
+		{Op: "add", Path: "/Rows/0", Value: testRow{Name: "first"}},
+		{Op: "test", Path: "/Memory/Heap", Value: 99},
+	}
+	if err := proxy.ApplyPatch(patch); !errors.Is(err, ErrPatchTest) {
+		t.Fatalf("Ожидали ErrPatchTest, получили %v", err)
+	}
+
+	want := newTestReport()
+	want.Memory.Stack = 4
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("Patch должен откатиться: ожидали %+v, получили %+v", want, report)
+	}
+	if history := proxy.GetHistory(); len(history) != 1 || history[0].Field != "Memory.Stack" {
+		t.Errorf("История должна вернуться к состоянию до Patch: %+v", history)
+	}
+	if err := proxy.Undo(); err != nil || report.Memory.Stack != 2 {
+		t.Errorf("Undo после отката должен отменять изменение до Patch: %v, Stack %d", err, report.Memory.Stack)
+	}
+}
+
+// TestApplyPatchTestNull проверяем, что test с null совпадает с nil-срезом и nil-указателем
+func TestApplyPatchTestNull(t *testing.T) {
+	report := &testReport{}
+	proxy := NewReactiveProxy(report)
+
+	patch := Patch{
+		{Op: "test", Path: "/Rows", Value: nil},
+		{Op: "test", Path: "/Owner", Value: nil},
+		{Op: "test", Path: "/Labels", Value: nil},
+	}
+	if err := proxy.ApplyPatch(patch); err != nil {
+		t.Errorf("Неожиданная ошибка %v", err)
+	}
+	if err := proxy.ApplyPatch(Patch{{Op: "test", Path: "/Rows", Value: []testRow{}}}); !errors.Is(err, ErrPatchTest) {
+		t.Errorf("Пустой срез не совпадает с null, получили %v", err)
+	}
+}
+
+// TestPatchMarshal проверяем, что value пишется и для null
+func TestPatchMarshal(t *testing.T) {
+	data, err := json.Marshal(Patch{
+		{Op: "replace", Path: "/Owner", Value: nil},
+		{Op: "remove", Path: "/Rows/0"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `[{"op":"replace","path":"/Owner","value":null},{"op":"remove","path":"/Rows/0"}]`
+	if string(data) != want {
+		t.Errorf("Ожидали %s, получили %s", want, data)
+	}
+}
@@ -0,0 +1,326 @@
+package proxy
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// pathSegment Шаг пути: поле структуры или индекс среза/ключ map
+type pathSegment struct {
+	field   string
+	index   string
+	isIndex bool
+}
+
+// fieldPath Разобранный путь вида Memory.Heap, Rows[3].Name или Labels[env]
+type fieldPath []pathSegment
+
+// parsePath Разобрать путь. Путь начинается с имени поля; ключ в [] может содержать любые символы, кроме ]
+func parsePath(path string) (fieldPath, error) {
+	segments := make(fieldPath, 0, strings.Count(path, ".")+1)
+
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '[':
+			if len(segments) == 0 {
+				return nil, fmt.Errorf("%w: %q начинается с индекса", ErrInvalidPath, path)
+			}
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: %q: нет закрывающей ]", ErrInvalidPath, path)
+			}
+			segments = append(segments, pathSegment{index: path[i+1 : i+end], isIndex: true})
+			i += end + 1
+
+		case '.':
+			if len(segments) == 0 {
+				return nil, fmt.Errorf("%w: %q начинается с точки", ErrInvalidPath, path)
+			}
+			i++
+			fallthrough
+
+		default:
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("%w: %q: пустое имя поля", ErrInvalidPath, path)
+			}
+			segments = append(segments, pathSegment{field: path[i : i+end]})
+			i += end
+		}
+	}
+
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("%w: пустой путь", ErrInvalidPath)
+	}
+	return segments, nil
+}
+
+// String Путь в каноническом виде
+func (p fieldPath) String() string {
+	var b strings.Builder
+	for i, segment := range p {
+		switch {
+		case segment.isIndex:
+			b.WriteString("[" + segment.index + "]")
+		case i > 0:
+			b.WriteString("." + segment.field)
+		default:
+			b.WriteString(segment.field)
+		}
+	}
+	return b.String()
+}
+
+// isDescendantOf Лежит ли путь строго внутри ancestor
+func (p fieldPath) isDescendantOf(ancestor fieldPath) bool {
+	if len(p) <= len(ancestor) {
+		return false
+	}
+	for i := range ancestor {
+		if p[i] != ancestor[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// indirect Разыменовать указатели и интерфейсы
+func indirect(value reflect.Value, path fieldPath) (reflect.Value, error) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}, fmt.Errorf("%w: %s: nil", ErrInvalidPath, path)
+		}
+		value = value.Elem()
+	}
+	return value, nil
+}
+
+// structField Поле структуры по имени
+func structField(value reflect.Value, name string, path fieldPath) (reflect.Value, error) {
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("%w: %s: %s не структура", ErrInvalidPath, path, value.Type())
+	}
+
+	field, exists := value.Type().FieldByName(name)
+	if !exists {
+		return reflect.Value{}, fmt.Errorf("%w: %s", ErrUnknownField, path)
+	}
+	if !field.IsExported() {
+		return reflect.Value{}, fmt.Errorf("%w: %s", ErrUnexportedField, path)
+	}
+
+	// Поле встроенной структуры по nil-указателю недоступно
+	result, err := value.FieldByIndexErr(field.Index)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("%w: %s: %v", ErrInvalidPath, path, err)
+	}
+	return result, nil
+}
+
+// sliceElem Элемент среза или массива по индексу
+func sliceElem(value reflect.Value, index string, path fieldPath) (reflect.Value, error) {
+	i, err := strconv.Atoi(index)
+	if err != nil || i < 0 || i >= value.Len() {
+		return reflect.Value{}, fmt.Errorf("%w: %s: индекс вне диапазона 0..%d", ErrInvalidPath, path, value.Len()-1)
+	}
+	return value.Index(i), nil
+}
+
+// mapKey Ключ map из строки пути
+func mapKey(m reflect.Value, key string, path fieldPath) (reflect.Value, error) {
+	keyType := m.Type().Key()
+	result := reflect.New(keyType).Elem()
+
+	var err error
+	switch {
+	case keyType.Kind() == reflect.String:
+		result.SetString(key)
+	case isInt(keyType.Kind()):
+		var number int64
+		if number, err = strconv.ParseInt(key, 10, 64); err == nil && !result.OverflowInt(number) {
+			result.SetInt(number)
+		} else if err == nil {
+			err = strconv.ErrRange
+		}
+	case isUint(keyType.Kind()):
+		var number uint64
+		if number, err = strconv.ParseUint(key, 10, 64); err == nil && !result.OverflowUint(number) {
+			result.SetUint(number)
+		} else if err == nil {
+			err = strconv.ErrRange
+		}
+	case keyType.Kind() == reflect.Bool:
+		var flag bool
+		if flag, err = strconv.ParseBool(key); err == nil {
+			result.SetBool(flag)
+		}
+	default:
+		err = fmt.Errorf("ключи типа %s не поддерживаются", keyType)
+	}
+
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("%w: %s: %v", ErrInvalidPath, path, err)
+	}
+	return result, nil
+}
+
+// lookupPath Значение по пути. Элементы map возвращаются копиями
+func lookupPath(root reflect.Value, path fieldPath) (reflect.Value, error) {
+	current := root
+	for i, segment := range path {
+		prefix := path[:i+1]
+
+		var err error
+		if current, err = indirect(current, prefix); err != nil {
+			return reflect.Value{}, err
+		}
+
+		switch {
+		case !segment.isIndex:
+			current, err = structField(current, segment.field, prefix)
+		case current.Kind() == reflect.Map:
+			var key reflect.Value
+			if key, err = mapKey(current, segment.index, prefix); err == nil {
+				if current = current.MapIndex(key); !current.IsValid() {
+					err = fmt.Errorf("%w: %s: нет ключа", ErrUnknownField, prefix)
+				}
+			}
+		case current.Kind() == reflect.Slice || current.Kind() == reflect.Array:
+			current, err = sliceElem(current, segment.index, prefix)
+		default:
+			err = fmt.Errorf("%w: %s: %s не индексируется", ErrInvalidPath, prefix, current.Type())
+		}
+
+		if err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return current, nil
+}
+
+// lookupInterface Значение по пути внутри произвольного значения или nil, если путь не разрешается
+func lookupInterface(value interface{}, path fieldPath) interface{} {
+	result, err := lookupPath(reflect.ValueOf(value), path)
+	if err != nil || !result.CanInterface() {
+		return nil
+	}
+	return result.Interface()
+}
+
+// pathSlot Место записи по пути: адресуемое значение или ключ map
+type pathSlot struct {
+	value reflect.Value // адресуемое значение, если запись не в map
+	m     reflect.Value // map, если последний шаг — ключ
+	key   reflect.Value
+	// copies Элементы map не адресуемы: их изменённые копии записываются обратно
+	copies []pathSlot
+}
+
+// slotForPath Найти место записи по пути, копируя по дороге элементы map
+func slotForPath(root reflect.Value, path fieldPath) (*pathSlot, error) {
+	slot := &pathSlot{}
+	current := root
+
+	for i, segment := range path {
+		prefix := path[:i+1]
+		last := i == len(path)-1
+
+		var err error
+		if current, err = indirect(current, prefix); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case !segment.isIndex:
+			current, err = structField(current, segment.field, prefix)
+		case current.Kind() == reflect.Map:
+			var key reflect.Value
+			if key, err = mapKey(current, segment.index, prefix); err != nil {
+				return nil, err
+			}
+			if last {
+				if current.IsNil() {
+					return nil, fmt.Errorf("%w: %s: map не инициализирована", ErrInvalidPath, prefix)
+				}
+				slot.m, slot.key = current, key
+				return slot, nil
+			}
+
+			elem := current.MapIndex(key)
+			if !elem.IsValid() {
+				return nil, fmt.Errorf("%w: %s: нет ключа", ErrUnknownField, prefix)
+			}
+			copied := reflect.New(elem.Type()).Elem()
+			copied.Set(elem)
+			slot.copies = append(slot.copies, pathSlot{m: current, key: key, value: copied})
+			current = copied
+		case current.Kind() == reflect.Slice || current.Kind() == reflect.Array:
+			current, err = sliceElem(current, segment.index, prefix)
+		default:
+			err = fmt.Errorf("%w: %s: %s не индексируется", ErrInvalidPath, prefix, current.Type())
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !current.CanSet() {
+		return nil, fmt.Errorf("%w: %s", ErrUnexportedField, path)
+	}
+	slot.value = current
+	return slot, nil
+}
+
+// Type Тип значения в месте записи
+func (s *pathSlot) Type() reflect.Type {
+	if s.m.IsValid() {
+		return s.m.Type().Elem()
+	}
+	return s.value.Type()
+}
+
+// Get Текущее значение; для отсутствующего ключа map — nil
+func (s *pathSlot) Get() interface{} {
+	if s.m.IsValid() {
+		value := s.m.MapIndex(s.key)
+		if !value.IsValid() {
+			return nil
+		}
+		return value.Interface()
+	}
+	return s.value.Interface()
+}
+
+// Exists Есть ли значение: false только для отсутствующего ключа map
+func (s *pathSlot) Exists() bool {
+	return !s.m.IsValid() || s.m.MapIndex(s.key).IsValid()
+}
+
+// Delete Удалить ключ map и вернуть изменённые копии элементов map на место
+func (s *pathSlot) Delete() {
+	s.m.SetMapIndex(s.key, reflect.Value{})
+	s.writeBack()
+}
+
+// Set Записать значение и вернуть изменённые копии элементов map на место
+func (s *pathSlot) Set(value reflect.Value) {
+	if s.m.IsValid() {
+		s.m.SetMapIndex(s.key, value)
+	} else {
+		s.value.Set(value)
+	}
+	s.writeBack()
+}
+
+func (s *pathSlot) writeBack() {
+	for i := len(s.copies) - 1; i >= 0; i-- {
+		write := s.copies[i]
+		write.m.SetMapIndex(write.key, write.value)
+	}
+}
@@ -0,0 +1,144 @@
+package proxy
+
+import (
+	"errors"
+	"testing"
+)
+
+type testMemory struct {
+	Heap  int
+	Stack int
+}
+
+type testRow struct {
+	Name string
+}
+
+type testReport struct {
+	Memory  testMemory
+	Rows    []testRow
+	Labels  map[string]string
+	Servers map[string]testRow
+	Owner   *testRow
+}
+
+func newTestReport() *testReport {
+	return &testReport{
+		Memory:  testMemory{Heap: 10, Stack: 2},
+		Rows:    []testRow{{Name: "a"}, {Name: "b"}},
+		Labels:  map[string]string{"env": "dev"},
+		Servers: map[string]testRow{"db": {Name: "postgres"}},
+	}
+}
+
+// TestParsePath проверяем разбор путей
+func TestParsePath(t *testing.T) {
+	valid := []string{"AllocMB", "Memory.Heap", "Rows[3].Name", "Labels[env]", "Servers[db].Name", "Labels[a.b]"}
+	for _, path := range valid {
+		parsed, err := parsePath(path)
+		if err != nil {
+			t.Errorf("%s: неожиданная ошибка %v", path, err)
+			continue
+		}
+		if parsed.String() != path {
+			t.Errorf("Ожидали %s, получили %s", path, parsed)
+		}
+	}
+
+	invalid := []string{"", ".Heap", "[0]", "Memory.", "Memory..Heap", "Rows[1"}
+	for _, path := range invalid {
+		if _, err := parsePath(path); !errors.Is(err, ErrInvalidPath) {
+			t.Errorf("%q: ожидали ErrInvalidPath, получили %v", path, err)
+		}
+	}
+}
+
+// TestNestedGetSet проверяем чтение и запись по вложенным путям
+func TestNestedGetSet(t *testing.T) {
+	report := newTestReport()
+	proxy := NewReactiveProxy(report)
+
+	if proxy.Get("Memory.Heap") != 10 {
+		t.Errorf("Ожидали 10, получили %v", proxy.Get("Memory.Heap"))
+	}
+	if proxy.Get("Rows[1].Name") != "b" {
+		t.Errorf("Ожидали b, получили %v", proxy.Get("Rows[1].Name"))
+	}
+	if proxy.Get("Labels[env]") != "dev" {
+		t.Errorf("Ожидали dev, получили %v", proxy.Get("Labels[env]"))
+	}
+
+	for path, value := range map[string]interface{}{
+		"Memory.Heap":      20,
+		"Rows[0].Name":     "c",
+		"Labels[env]":      "prod",
+		"Labels[region]":   "eu",
+		"Servers[db].Name": "mysql",
+	} {
+		if err := proxy.Set(path, value); err != nil {
+			t.Errorf("%s: неожиданная ошибка %v", path, err)
+		}
+	}
+
+	if report.Memory.Heap != 20 || report.Rows[0].Name != "c" {
+		t.Errorf("Вложенные поля не изменились: %+v", report)
+	}
+	if report.Labels["env"] != "prod" || report.Labels["region"] != "eu" {
+		t.Errorf("Ключи map не изменились: %v", report.Labels)
+	}
+	if report.Servers["db"].Name != "mysql" {
+		t.Errorf("Поле элемента map не изменилось: %v", report.Servers)
+	}
+}
+
+// TestNestedErrors проверяем ошибки неразрешимых путей
+func TestNestedErrors(t *testing.T) {
+	proxy := NewProxy(newTestReport())
+
+	tests := []struct {
+		path string
+		want error
+	}{
+		{"Memory.Missing", ErrUnknownField},
+		{"Rows[5].Name", ErrInvalidPath},
+		{"Rows[x]", ErrInvalidPath},
+		{"Owner.Name", ErrInvalidPath},
+		{"Memory[0]", ErrInvalidPath},
+		{"Servers[cache].Name", ErrUnknownField},
+	}
+
+	for _, tt := range tests {
+		if err := proxy.Set(tt.path, "x"); !errors.Is(err, tt.want) {
+			t.Errorf("Set %s: ожидали %v, получили %v", tt.path, tt.want, err)
+		}
+	}
+
+	if _, err := proxy.Get("Labels[missing]"); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Ожидали ErrUnknownField, получили %v", err)
+	}
+}
+
+// TestWatchNested проверяем наблюдателей точного пути и предков
+func TestWatchNested(t *testing.T) {
+	proxy := NewReactiveProxy(newTestReport())
+
+	var calls []string
+	proxy.Watch("Memory.Heap", "Set", func(fieldName string, oldValue, newValue interface{}) {
+		calls = append(calls, fieldName)
+		if oldValue == newValue {
+			t.Errorf("Наблюдатель не должен вызываться без изменения: %v", newValue)
+		}
+	})
+
+	proxy.Set("Memory.Heap", 11)
+	// Замена предка с другим Heap
+	proxy.Set("Memory", testMemory{Heap: 12, Stack: 2})
+	// Замена предка без изменения Heap
+	proxy.Set("Memory", testMemory{Heap: 12, Stack: 3})
+	// Соседнее поле
+	proxy.Set("Memory.Stack", 4)
+
+	if len(calls) != 2 {
+		t.Errorf("Ожидали 2 вызова, получили %v", calls)
+	}
+}
@@ -1,43 +1,169 @@
 package proxy
 
 import (
+	logger "Guess/internal"
+	"Guess/internal/reactivity"
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"sync"
+	"time"
 )
 
 // WatcherFunc Тип для функций-наблюдателей
 type WatcherFunc func(fieldName string, oldValue, newValue interface{})
 
-// ReactiveProxy Реактивный Proxy
+// ReactiveProxy Реактивный Proxy. Get подписывает активный эффект на поле,
+// Set перезапускает только эффекты, читавшие изменённое поле
 type ReactiveProxy struct {
 	target      interface{}
 	getWatchers map[string][]WatcherFunc
 	setWatchers map[string][]WatcherFunc
 	history     []ChangeRecord
-}
+	clock       reactivity.Clock
+	// Отменённые изменения для Redo, размер истории и признак повтора записей (см. history.go)
+	redo        []ChangeRecord
+	historySize int
+	replaying   bool
+	// journal Изменения идущего ApplyPatch, по которым он откатывается при ошибке (см. patch.go)
+	journal *[]ChangeRecord
+	// interceptors Цепочка обработчиков перед записью (см. intercept.go)
+	interceptors []SetInterceptor
+	// runtime Среда, в которой чтения полей подписывают эффекты, а записи их перезапускают
+	runtime *reactivity.Runtime
 
-// ChangeRecord Запись об изменении
-type ChangeRecord struct {
-	Field    string
-	OldValue interface{}
-	NewValue interface{}
-	Time     string
+	// readonly Set запрещён
+	readonly bool
+	// shallow Изменение определяется сравнением ==, без обхода вложенных структур
+	shallow bool
+	// root Proxy, чьи наблюдатели и история используются представлением (nil у самого Proxy)
+	root *ReactiveProxy
 }
 
-const MaxHistorySize = 2
+// Ошибки доступа к полям
+var (
+	// ErrReadonly Попытка изменить значение через readonly Proxy
+	ErrReadonly = errors.New("proxy только для чтения")
+	// ErrUnknownField В структуре нет поля с таким именем
+	ErrUnknownField = errors.New("неизвестное поле")
+	// ErrUnexportedField Поле не экспортируется, его нельзя прочитать или изменить
+	ErrUnexportedField = errors.New("поле не экспортируется")
+	// ErrTypeMismatch Значение нельзя присвоить полю
+	ErrTypeMismatch = errors.New("несовпадение типов")
+	// ErrInvalidPath Путь не разбирается или не ведёт к значению: индекс вне диапазона, nil по дороге
+	ErrInvalidPath = errors.New("некорректный путь")
+)
 
-// NewReactiveProxy Конструктор
+// NewReactiveProxy Конструктор в среде реактивности по умолчанию
 func NewReactiveProxy(target interface{}) *ReactiveProxy {
+	return NewReactiveProxyIn(reactivity.DefaultRuntime(), target)
+}
+
+// NewReactiveProxyIn Конструктор в заданной среде реактивности
+func NewReactiveProxyIn(rt *reactivity.Runtime, target interface{}) *ReactiveProxy {
 	return &ReactiveProxy{
 		target:      target,
 		getWatchers: make(map[string][]WatcherFunc),
 		setWatchers: make(map[string][]WatcherFunc),
 		history:     make([]ChangeRecord, 0),
+		clock:       reactivity.SystemClock,
+		redo:        make([]ChangeRecord, 0),
+		historySize: DefaultHistorySize,
+		runtime:     rt,
+	}
+}
+
+// NewReadonlyProxy Proxy только для чтения: Set возвращает ErrReadonly
+func NewReadonlyProxy(target interface{}) *ReactiveProxy {
+	p := NewReactiveProxy(target)
+	p.readonly = true
+	return p
+}
+
+// NewShallowProxy Proxy, который считает значение изменившимся по сравнению ==.
+// Вложенные структуры не обходятся: новая map или срез всегда считаются изменением
+func NewShallowProxy(target interface{}) *ReactiveProxy {
+	p := NewReactiveProxy(target)
+	p.shallow = true
+	return p
+}
+
+// Readonly Представление только для чтения над тем же объектом.
+// Наблюдатели и история общие с исходным Proxy, Set возвращает ErrReadonly
+func (p *ReactiveProxy) Readonly() *ReactiveProxy {
+	return &ReactiveProxy{
+		target:   p.target,
+		readonly: true,
+		shallow:  p.shallow,
+		root:     p.raw(),
+		runtime:  p.runtime,
+	}
+}
+
+// IsReadonly Запрещён ли Set
+func (p *ReactiveProxy) IsReadonly() bool {
+	return p.readonly
+}
+
+// IsShallow Сравниваются ли значения без обхода вложенных структур
+func (p *ReactiveProxy) IsShallow() bool {
+	return p.shallow
+}
+
+// raw Proxy, которому принадлежат наблюдатели и история
+func (p *ReactiveProxy) raw() *ReactiveProxy {
+	if p.root != nil {
+		return p.root
+	}
+	return p
+}
+
+// ToRaw Исходный объект за Proxy или представлением. Значения, не являющиеся Proxy, возвращаются как есть
+func ToRaw(value interface{}) interface{} {
+	if p, ok := value.(*ReactiveProxy); ok {
+		return p.Original()
+	}
+	return value
+}
+
+// WatchOption Настройка наблюдателя
+type WatchOption func(config *watchConfig)
+
+type watchConfig struct {
+	debounce time.Duration
+	throttle time.Duration
+}
+
+// WithDebounce Вызывать наблюдателя только после паузы в изменениях длиной wait.
+// OldValue — значение до первого изменения серии, NewValue — после последнего
+func WithDebounce(wait time.Duration) WatchOption {
+	return func(config *watchConfig) {
+		config.debounce = wait
 	}
 }
 
+// WithThrottle Вызывать наблюдателя не чаще раза в wait: первое изменение сразу,
+// остальные внутри окна — одним вызовом в конце окна
+func WithThrottle(wait time.Duration) WatchOption {
+	return func(config *watchConfig) {
+		config.throttle = wait
+	}
+}
+
+// SetClock Задать часы для отложенных наблюдателей (nil — SystemClock).
+// Влияет на наблюдателей, добавленных после вызова
+func (p *ReactiveProxy) SetClock(clock reactivity.Clock) {
+	if clock == nil {
+		clock = reactivity.SystemClock
+	}
+	p.raw().clock = clock
+}
+
 // Уведомить всех наблюдателей
 func (p *ReactiveProxy) notify(fieldName string, key string, oldValue, newValue interface{}) {
+	p = p.raw()
+
 	switch key {
 	case "Get":
 		if watchers, exists := p.getWatchers[fieldName]; exists {
@@ -52,35 +178,71 @@ func (p *ReactiveProxy) notify(fieldName string, key string, oldValue, newValue
 				watcher(fieldName, oldValue, newValue)
 			}
 		}
+		p.notifyDescendants(fieldName, oldValue, newValue)
 		break
 	default:
 		fmt.Printf("Unknown watcher key: %s\n", key)
 	}
+}
 
-	if oldValue != newValue {
-		// Записываем в историю
-		p.history = append(p.history, ChangeRecord{
-			Field:    fieldName,
-			OldValue: oldValue,
-			NewValue: newValue,
-			Time:     fmt.Sprintf("%d", len(p.history)+1),
-		})
+// notifyDescendants Оповестить наблюдателей вложенных путей о замене предка:
+// наблюдатель Memory.Heap срабатывает при Set("Memory", ...), если Heap изменился
+func (p *ReactiveProxy) notifyDescendants(fieldName string, oldValue, newValue interface{}) {
+	ancestor, err := parsePath(fieldName)
+	if err != nil {
+		return
+	}
+
+	paths := make([]string, 0)
+	for watched := range p.setWatchers {
+		paths = append(paths, watched)
+	}
+	sort.Strings(paths)
+
+	for _, watched := range paths {
+		path, err := parsePath(watched)
+		if err != nil || !path.isDescendantOf(ancestor) {
+			continue
+		}
+
+		relative := path[len(ancestor):]
+		oldNested := lookupInterface(oldValue, relative)
+		newNested := lookupInterface(newValue, relative)
+		if p.equal(oldNested, newNested) {
+			continue
+		}
 
-		if len(p.history) > MaxHistorySize {
-			// Удаляем старые записи (FIFO)
-			copy(p.history, p.history[1:])
-			p.history = p.history[:MaxHistorySize]
+		for _, watcher := range p.setWatchers[watched] {
+			watcher(watched, oldNested, newNested)
 		}
 	}
 }
 
-// Original Получить оригинальную структуру
+// Original Получить оригинальную структуру (см. также ToRaw)
 func (p *ReactiveProxy) Original() interface{} {
 	return p.target
 }
 
-// Watch Добавить наблюдателя за полем
-func (p *ReactiveProxy) Watch(fieldName string, key string, watcher WatcherFunc) {
+// Watch Добавить наблюдателя за полем или путем (Memory.Heap, Rows[3].Name, Labels[env]).
+// Наблюдатель Set срабатывает и при замене любого предка пути
+func (p *ReactiveProxy) Watch(fieldName string, key string, watcher WatcherFunc, opts ...WatchOption) {
+	// Наблюдатели хранятся под каноническим путём: псевдонимы из тега reactive:"name=..."
+	// заменяются именами полей
+	if path, flags, err := p.parse(fieldName); err == nil {
+		if flags.ignore {
+			logger.WarnLog("proxy", fmt.Sprintf("поле %s не реактивно (reactive:\"-\"), наблюдатель не добавлен", path))
+			return
+		}
+		fieldName = path.String()
+	}
+
+	config := watchConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	watcher = p.limitWatcher(watcher, config)
+	p = p.raw()
+
 	switch key {
 	case "Get":
 		if p.getWatchers[fieldName] == nil {
@@ -100,50 +262,228 @@ func (p *ReactiveProxy) Watch(fieldName string, key string, watcher WatcherFunc)
 
 }
 
-// Get Получить значение
-func (p *ReactiveProxy) Get(fieldName string) interface{} {
-	value := reflect.ValueOf(p.target).Elem()
-	field := value.FieldByName(fieldName)
+// limitWatcher Обернуть наблюдателя в debounce или throttle
+func (p *ReactiveProxy) limitWatcher(watcher WatcherFunc, config watchConfig) WatcherFunc {
+	var limiter interface{ Call(fn func()) }
+	switch {
+	case config.debounce > 0:
+		limiter = reactivity.NewDebouncer(config.debounce, p.raw().clock)
+	case config.throttle > 0:
+		limiter = reactivity.NewThrottler(config.throttle, p.raw().clock)
+	default:
+		return watcher
+	}
+
+	// Старое значение запоминаем с первого изменения серии, новое берём из последнего
+	var mu sync.Mutex
+	pending := false
+	var firstOld interface{}
+
+	return func(fieldName string, oldValue, newValue interface{}) {
+		mu.Lock()
+		if !pending {
+			pending = true
+			firstOld = oldValue
+		}
+		seriesOld := firstOld
+		mu.Unlock()
 
-	if !field.IsValid() {
+		limiter.Call(func() {
+			mu.Lock()
+			pending = false
+			mu.Unlock()
+
+			watcher(fieldName, seriesOld, newValue)
+		})
+	}
+}
+
+// Get Получить значение по имени поля или пути: Memory.Heap, Rows[3].Name, Labels[env].
+// nil для неизвестного, неэкспортируемого поля или неразрешимого пути
+func (p *ReactiveProxy) Get(fieldName string) interface{} {
+	value, err := p.get(fieldName)
+	if err != nil {
 		return nil
 	}
+	return value
+}
+
+// Set Установить значение по имени поля или пути с уведомлением наблюдателей.
+// Ключ map по пути Labels[env] создаётся, если его нет.
+// Возвращает ошибку для неизвестного или неэкспортируемого поля, неразрешимого пути и значения чужого типа
+func (p *ReactiveProxy) Set(fieldName string, newValue interface{}) error {
+	return p.set(fieldName, newValue, false)
+}
+
+// parse Разобрать путь, заменив псевдонимы полей именами, и собрать настройки полей из тегов
+func (p *ReactiveProxy) parse(fieldName string) (fieldPath, fieldFlags, error) {
+	path, err := parsePath(fieldName)
+	if err != nil {
+		return nil, fieldFlags{}, err
+	}
+	return resolvePath(reflect.TypeOf(p.target), path)
+}
+
+func (p *ReactiveProxy) get(fieldName string) (interface{}, error) {
+	path, flags, err := p.parse(fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := lookupPath(reflect.ValueOf(p.target), path)
+	if err != nil {
+		return nil, err
+	}
+	result := value.Interface()
+
+	// Поля с reactive:"-" читаются без подписки и наблюдателей
+	if flags.ignore {
+		return result, nil
+	}
+	p.track(path)
 
 	// Уведомляем наблюдателей (старое значение совпадает с новым)
-	p.notify(fieldName, "Get", field.Interface(), field.Interface())
+	p.notify(path.String(), "Get", result, result)
 
-	return field.Interface()
+	return result, nil
 }
 
-// Set Установить значение с уведомлением наблюдателей
-func (p *ReactiveProxy) Set(fieldName string, newValue interface{}) {
-	value := reflect.ValueOf(p.target).Elem()
-	field := value.FieldByName(fieldName)
+// set Установить значение; convert разрешает преобразование совместимых типов
+func (p *ReactiveProxy) set(fieldName string, newValue interface{}, convert bool) error {
+	if p.readonly {
+		return readonlyError(fieldName)
+	}
 
-	if !field.IsValid() || !field.CanSet() {
-		return
+	path, flags, err := p.parse(fieldName)
+	if err != nil {
+		return err
+	}
+	if flags.readonly {
+		return readonlyError(path.String())
+	}
+
+	// Одна транзакция на Set: точный путь, предки и потомки перезапускают эффект один раз
+	p.runtime.Batch(func() {
+		err = p.setPath(path, flags, newValue, convert)
+	})
+	return err
+}
+
+// readonlyError Ошибка записи через readonly Proxy; пишется в лог, чтобы не потеряться в виджетах
+func readonlyError(fieldName string) error {
+	err := fmt.Errorf("%w: поле %s", ErrReadonly, fieldName)
+	logger.ErrorLog("proxy", err.Error())
+	return err
+}
+
+// setPath Записать значение по разобранному пути, уведомить наблюдателей и эффекты
+func (p *ReactiveProxy) setPath(path fieldPath, flags fieldFlags, newValue interface{}, convert bool) error {
+	slot, err := slotForPath(reflect.ValueOf(p.target), path)
+	if err != nil {
+		return err
+	}
+	oldValue := slot.Get()
+	existed := slot.Exists()
+
+	newValue, err = p.intercept(path.String(), oldValue, newValue)
+	if err != nil {
+		return fmt.Errorf("поле %s: %w", path, err)
 	}
 
-	oldValue := field.Interface()
+	assigned, err := assignableValue(newValue, slot.Type(), convert)
+	if err != nil {
+		return fmt.Errorf("поле %s: %w", path, err)
+	}
+	if err := validatePath(reflect.TypeOf(p.target), path, assigned); err != nil {
+		return fmt.Errorf("поле %s: %w", path, err)
+	}
+	newValue = assigned.Interface()
 
 	// Проверяем, действительно ли значение изменилось
-	if reflect.DeepEqual(oldValue, newValue) {
-		return
+	if p.equal(oldValue, newValue) {
+		return nil
 	}
 
 	// Устанавливаем новое значение
-	field.Set(reflect.ValueOf(newValue))
+	slot.Set(assigned)
+	p.raw().journalChange(path.String(), oldValue, newValue, !existed)
+
+	// Поля с reactive:"-" меняются молча: без наблюдателей, истории и эффектов
+	if flags.ignore {
+		return nil
+	}
 
 	// Уведомляем наблюдателей
-	p.notify(fieldName, "Set", oldValue, newValue)
+	p.notify(path.String(), "Set", oldValue, newValue)
+	if !flags.noHistory {
+		p.raw().record(path.String(), oldValue, newValue, !existed)
+	}
+	p.trigger(path)
+	return nil
+}
+
+// deletePath Удалить ключ map по пути (используется при отмене добавления ключа)
+func (p *ReactiveProxy) deletePath(path fieldPath) error {
+	slot, err := slotForPath(reflect.ValueOf(p.target), path)
+	if err != nil {
+		return err
+	}
+	if !slot.m.IsValid() {
+		return fmt.Errorf("%w: %s не ключ map", ErrInvalidPath, path)
+	}
+	if !slot.Exists() {
+		return nil
+	}
+
+	oldValue := slot.Get()
+	slot.Delete()
+	p.raw().journalChange(path.String(), oldValue, nil, false)
+
+	p.notify(path.String(), "Set", oldValue, nil)
+	p.trigger(path)
+	return nil
+}
+
+// subtreeKey Ключ, который срабатывает при замене значения по пути целиком
+func subtreeKey(path fieldPath) string {
+	return path.String() + ".*"
+}
+
+// track Подписать активный эффект на путь. Кроме самого пути эффект подписывается
+// на замену каждого предка: чтение Memory.Heap перезапустится от Set("Memory"),
+// но не от Set("Memory.Stack")
+func (p *ReactiveProxy) track(path fieldPath) {
+	p.runtime.Track(p.target, path.String())
+	for i := 1; i < len(path); i++ {
+		p.runtime.Track(p.target, subtreeKey(path[:i]))
+	}
 }
 
-// GetHistory Получить историю изменений
-func (p *ReactiveProxy) GetHistory() []ChangeRecord {
-	return p.history
+// trigger Перезапустить эффекты, читавшие путь, его предков и потомков
+func (p *ReactiveProxy) trigger(path fieldPath) {
+	p.runtime.Trigger(p.target, path.String())
+	p.runtime.Trigger(p.target, subtreeKey(path))
+	for i := 1; i < len(path); i++ {
+		p.runtime.Trigger(p.target, path[:i].String())
+	}
 }
 
-// ClearHistory Очистить историю
-func (p *ReactiveProxy) ClearHistory() {
-	p.history = make([]ChangeRecord, 0)
+// equal Совпадают ли значения: глубоко или, у shallow Proxy, по ==.
+// Сравнимость проверяется по значению, а не по типу: структура с полем any сравнима
+// по типу, но == паникует, если в поле лежит срез. Несравнимые значения считаются изменёнными
+func (p *ReactiveProxy) equal(oldValue, newValue interface{}) bool {
+	if !p.shallow {
+		return reflect.DeepEqual(oldValue, newValue)
+	}
+
+	if reflect.TypeOf(oldValue) != reflect.TypeOf(newValue) {
+		return false
+	}
+	if oldValue == nil {
+		return true
+	}
+	if !reflect.ValueOf(oldValue).Comparable() || !reflect.ValueOf(newValue).Comparable() {
+		return false
+	}
+	return oldValue == newValue
 }
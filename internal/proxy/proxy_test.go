@@ -1,7 +1,10 @@
 package proxy
 
 import (
+	"Guess/internal/reactivity"
+	"errors"
 	"testing"
+	"time"
 )
 
 type TestPerson struct {
@@ -119,7 +122,7 @@ func TestWatch(t *testing.T) {
 	}
 }
 
-// TestHistory проверяем историю изменений
+// TestHistory проверяем историю изменений: пишутся только изменения через Set, чтения в неё не попадают
 func TestHistory(t *testing.T) {
 	person := &TestPerson{Name: "Лена", Age: 22}
 	proxy := NewReactiveProxy(person)
@@ -133,8 +136,8 @@ func TestHistory(t *testing.T) {
 
 	// Проверяем историю
 	history := proxy.GetHistory()
-	if len(history) != 5 {
-		t.Errorf("Ожидали 5 записи в истории, получили %d", len(history))
+	if len(history) != 3 {
+		t.Fatalf("Ожидали 3 записи в истории, получили %d", len(history))
 	}
 
 	// Проверяем первую запись
@@ -143,7 +146,7 @@ func TestHistory(t *testing.T) {
 	}
 
 	// Проверяем последнюю запись
-	if history[4].Field != "Age" || history[4].OldValue != 25 {
+	if history[2].Field != "Name" || history[2].OldValue != "Оля" || history[2].NewValue != "Света" {
 		t.Error("Последняя запись в истории неверная")
 	}
 
@@ -153,6 +156,18 @@ func TestHistory(t *testing.T) {
 	if len(history) != 0 {
 		t.Error("История должна быть пустой после очистки")
 	}
+
+	// По умолчанию хранится DefaultHistorySize последних записей
+	for age := 1; age <= DefaultHistorySize+5; age++ {
+		proxy.Set("Age", age)
+	}
+	history = proxy.GetHistory()
+	if len(history) != DefaultHistorySize {
+		t.Fatalf("Ожидали %d записей, получили %d", DefaultHistorySize, len(history))
+	}
+	if last := history[len(history)-1]; last.NewValue != DefaultHistorySize+5 {
+		t.Errorf("Последняя запись должна быть самой свежей, получили %v", last.NewValue)
+	}
 }
 
 // TestGetSameValue проверяем, что при попытке Get будет вызвано уведомление
@@ -171,10 +186,10 @@ func TestGetSameValue(t *testing.T) {
 		t.Error("Наблюдатель должен вызываться при наличии поля")
 	}
 
-	// История должна быть пустой
+	// Чтение не попадает в историю
 	history := proxy.GetHistory()
-	if len(history) == 0 {
-		t.Error("История не должна быть пустой")
+	if len(history) != 0 {
+		t.Errorf("История должна быть пустой после Get, получили %d записей", len(history))
 	}
 }
 
@@ -202,3 +217,162 @@ func TestSetSameValue(t *testing.T) {
 		t.Error("История должна быть пустой при установке того же значения")
 	}
 }
+
+// TestWatchDebounce проверяем, что серия Set вызывает отложенного наблюдателя один раз
+func TestWatchDebounce(t *testing.T) {
+	person := &TestPerson{Name: "Аня", Age: 20}
+	proxy := NewReactiveProxy(person)
+	clock := reactivity.NewFakeClock(time.Unix(0, 0))
+	proxy.SetClock(clock)
+
+	calls := 0
+	var gotOld, gotNew interface{}
+	proxy.Watch("Age", "Set", func(fieldName string, oldValue, newValue interface{}) {
+		calls++
+		gotOld, gotNew = oldValue, newValue
+	}, WithDebounce(time.Second))
+
+	proxy.Set("Age", 21)
+	proxy.Set("Age", 22)
+	proxy.Set("Age", 23)
+
+	if calls != 0 {
+		t.Fatalf("До паузы наблюдатель не должен вызываться, вызовов: %d", calls)
+	}
+
+	clock.Advance(time.Second)
+	if calls != 1 {
+		t.Fatalf("Ожидали 1 вызов, получили %d", calls)
+	}
+	if gotOld != 20 || gotNew != 23 {
+		t.Errorf("Ожидали 20 -> 23, получили %v -> %v", gotOld, gotNew)
+	}
+}
+
+// TestWatchThrottle проверяем вызов сразу и один вызов в конце окна
+func TestWatchThrottle(t *testing.T) {
+	person := &TestPerson{Name: "Гоша", Age: 40}
+	proxy := NewReactiveProxy(person)
+	clock := reactivity.NewFakeClock(time.Unix(0, 0))
+	proxy.SetClock(clock)
+
+	values := make([]interface{}, 0)
+	proxy.Watch("Age", "Set", func(fieldName string, oldValue, newValue interface{}) {
+		values = append(values, newValue)
+	}, WithThrottle(time.Second))
+
+	proxy.Set("Age", 41)
+	proxy.Set("Age", 42)
+	proxy.Set("Age", 43)
+
+	if len(values) != 1 || values[0] != 41 {
+		t.Fatalf("Первое изменение должно прийти сразу, получили %v", values)
+	}
+
+	clock.Advance(time.Second)
+	if len(values) != 2 || values[1] != 43 {
+		t.Errorf("В конце окна ожидали последнее значение, получили %v", values)
+	}
+}
+
+// TestReadonly проверяем, что readonly-представление не даёт менять значения, но видит изменения
+func TestReadonly(t *testing.T) {
+	person := &TestPerson{Name: "Лена", Age: 28}
+	proxy := NewReactiveProxy(person)
+	view := proxy.Readonly()
+
+	getCalls := 0
+	proxy.Watch("Name", "Get", func(fieldName string, oldValue, newValue interface{}) {
+		getCalls++
+	})
+
+	if err := view.Set("Name", "Оля"); !errors.Is(err, ErrReadonly) {
+		t.Errorf("Ожидали ErrReadonly, получили %v", err)
+	}
+	if person.Name != "Лена" {
+		t.Error("Readonly-представление не должно менять исходную структуру")
+	}
+
+	if err := proxy.Set("Name", "Оля"); err != nil {
+		t.Fatalf("Исходный Proxy должен разрешать Set, получили %v", err)
+	}
+	if view.Get("Name") != "Оля" {
+		t.Errorf("Представление должно видеть изменения, получили %v", view.Get("Name"))
+	}
+	if getCalls != 1 {
+		t.Errorf("Get через представление должен оповещать наблюдателей Proxy, вызовов: %d", getCalls)
+	}
+	if len(view.GetHistory()) != 1 {
+		t.Errorf("История должна быть общей, получили %d записей", len(view.GetHistory()))
+	}
+}
+
+// TestShallow проверяем, что shallow Proxy не сравнивает вложенные значения
+func TestShallow(t *testing.T) {
+	type Team struct {
+		Members []string
+	}
+	team := &Team{Members: []string{"Вася"}}
+
+	calls := 0
+	deep := NewReactiveProxy(team)
+	deep.Watch("Members", "Set", func(fieldName string, oldValue, newValue interface{}) {
+		calls++
+	})
+	deep.Set("Members", []string{"Вася"})
+	if calls != 0 {
+		t.Errorf("Обычный Proxy не должен оповещать о равном срезе, вызовов: %d", calls)
+	}
+
+	shallow := NewShallowProxy(team)
+	shallow.Watch("Members", "Set", func(fieldName string, oldValue, newValue interface{}) {
+		calls++
+	})
+	shallow.Set("Members", []string{"Вася"})
+	if calls != 1 {
+		t.Errorf("Shallow Proxy должен считать новый срез изменением, вызовов: %d", calls)
+	}
+}
+
+// TestShallowAnyField проверяем shallow Proxy на структуре с полем any, в котором лежит срез:
+// тип сравним, но значение нет, и такое значение считается изменённым
+func TestShallowAnyField(t *testing.T) {
+	type Inner struct {
+		X any
+	}
+	type Outer struct {
+		In Inner
+	}
+	outer := &Outer{In: Inner{X: []int{1}}}
+
+	calls := 0
+	shallow := NewShallowProxy(outer)
+	shallow.Watch("In", "Set", func(fieldName string, oldValue, newValue interface{}) {
+		calls++
+	})
+	if err := shallow.Set("In", Inner{X: []int{1}}); err != nil {
+		t.Fatalf("Set не должен возвращать ошибку: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Несравнимое значение должно считаться изменением, вызовов: %d", calls)
+	}
+
+	shallow.Set("In", Inner{X: 2})
+	shallow.Set("In", Inner{X: 2})
+	if calls != 2 {
+		t.Errorf("Равное сравнимое значение не должно оповещать, вызовов: %d", calls)
+	}
+}
+
+// TestToRaw проверяем получение исходного объекта
+func TestToRaw(t *testing.T) {
+	person := &TestPerson{Name: "Ира", Age: 19}
+	view := NewReactiveProxy(person).Readonly()
+
+	if ToRaw(view) != person {
+		t.Error("ToRaw должен возвращать исходную структуру")
+	}
+	if ToRaw(person) != person {
+		t.Error("ToRaw должен возвращать не-Proxy значения как есть")
+	}
+}
@@ -0,0 +1,72 @@
+package proxy
+
+import (
+	"Guess/internal/reactivity"
+	"testing"
+)
+
+// TestProxyTracksFields проверяем, что эффект перезапускается только от прочитанных полей
+func TestProxyTracksFields(t *testing.T) {
+	rt := reactivity.NewRuntime()
+	person := &TestPerson{Name: "Дима", Age: 35}
+	proxy := NewReactiveProxyIn(rt, person)
+
+	runs := 0
+	rt.WatchEffect(func() {
+		proxy.Get("Name")
+		runs++
+	})
+
+	proxy.Set("Age", 36)
+	if runs != 1 {
+		t.Errorf("Изменение непрочитанного поля не должно перезапускать эффект, запусков: %d", runs)
+	}
+
+	proxy.Set("Name", "Саша")
+	if runs != 2 {
+		t.Errorf("Изменение прочитанного поля должно перезапускать эффект, запусков: %d", runs)
+	}
+
+	// Представление только для чтения делит зависимости с исходным Proxy
+	view := proxy.Readonly()
+	viewRuns := 0
+	rt.WatchEffect(func() {
+		view.Get("Age")
+		viewRuns++
+	})
+	proxy.Set("Age", 37)
+	if viewRuns != 2 {
+		t.Errorf("Эффект представления должен видеть Set исходного Proxy, запусков: %d", viewRuns)
+	}
+}
+
+// TestProxyTracksPaths проверяем подписку на вложенные пути, их предков и потомков
+func TestProxyTracksPaths(t *testing.T) {
+	rt := reactivity.NewRuntime()
+	proxy := NewReactiveProxyIn(rt, newTestReport())
+
+	heapRuns, memoryRuns := 0, 0
+	rt.WatchEffect(func() {
+		proxy.Get("Memory.Heap")
+		heapRuns++
+	})
+	rt.WatchEffect(func() {
+		proxy.Get("Memory")
+		memoryRuns++
+	})
+
+	proxy.Set("Memory.Stack", 5)
+	if heapRuns != 1 || memoryRuns != 2 {
+		t.Errorf("Соседнее поле будит только предка: heap %d, memory %d", heapRuns, memoryRuns)
+	}
+
+	proxy.Set("Memory", testMemory{Heap: 1, Stack: 1})
+	if heapRuns != 2 || memoryRuns != 3 {
+		t.Errorf("Замена предка будит всех: heap %d, memory %d", heapRuns, memoryRuns)
+	}
+
+	proxy.Set("Memory.Heap", 2)
+	if heapRuns != 3 || memoryRuns != 4 {
+		t.Errorf("Изменение пути будит его и предка: heap %d, memory %d", heapRuns, memoryRuns)
+	}
+}
@@ -0,0 +1,193 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidReactiveTag Тег reactive не разбирается
+var ErrInvalidReactiveTag = errors.New("некорректный тег reactive")
+
+// typeInfo Разобранные теги полей структуры. Считается один раз на тип
+type typeInfo struct {
+	fields map[string]*fieldInfo
+	// aliases Псевдоним из reactive:"name=..." -> имя поля
+	aliases map[string]string
+}
+
+// fieldInfo Настройки поля из тегов
+type fieldInfo struct {
+	// rules Проверки из тега validate; ruleErr — ошибка разбора тега
+	rules   []validationRule
+	ruleErr error
+
+	// Тег reactive:"-", "readonly", "nohistory", "name=alloc" (через запятую)
+	ignore    bool
+	readonly  bool
+	noHistory bool
+	alias     string
+	tagErr    error
+}
+
+// fieldFlags Настройки, накопленные по всем полям пути: readonly у Memory делает
+// недоступным для записи и Memory.Heap
+type fieldFlags struct {
+	ignore    bool
+	readonly  bool
+	noHistory bool
+}
+
+var typeCache sync.Map // reflect.Type -> *typeInfo
+
+// typeInfoFor Теги полей структуры t из кеша
+func typeInfoFor(t reflect.Type) *typeInfo {
+	if cached, ok := typeCache.Load(t); ok {
+		return cached.(*typeInfo)
+	}
+
+	info := &typeInfo{
+		fields:  make(map[string]*fieldInfo),
+		aliases: make(map[string]string),
+	}
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() {
+			continue
+		}
+
+		fi := &fieldInfo{}
+		if tag, ok := field.Tag.Lookup("validate"); ok {
+			fi.rules, fi.ruleErr = parseRules(tag)
+		}
+		if tag, ok := field.Tag.Lookup("reactive"); ok {
+			fi.tagErr = parseReactiveTag(fi, tag)
+		}
+		if fi.alias != "" {
+			info.aliases[fi.alias] = field.Name
+		}
+		info.fields[field.Name] = fi
+	}
+
+	cached, _ := typeCache.LoadOrStore(t, info)
+	return cached.(*typeInfo)
+}
+
+// parseReactiveTag Разобрать тег reactive в настройки поля
+func parseReactiveTag(fi *fieldInfo, tag string) error {
+	for _, option := range strings.Split(tag, ",") {
+		option = strings.TrimSpace(option)
+		switch {
+		case option == "":
+		case option == "-":
+			fi.ignore = true
+		case option == "readonly":
+			fi.readonly = true
+		case option == "nohistory":
+			fi.noHistory = true
+		case strings.HasPrefix(option, "name="):
+			fi.alias = strings.TrimPrefix(option, "name=")
+			if fi.alias == "" || strings.ContainsAny(fi.alias, ".[]") {
+				return fmt.Errorf("%w: %q: имя не может быть пустым или содержать . [ ]", ErrInvalidReactiveTag, option)
+			}
+		default:
+			return fmt.Errorf("%w: неизвестная настройка %q", ErrInvalidReactiveTag, option)
+		}
+	}
+	return nil
+}
+
+// resolvePath Заменить псевдонимы полей в пути именами и собрать настройки полей.
+// Неизвестные поля оставляются как есть: о них сообщит поиск значения
+func resolvePath(root reflect.Type, path fieldPath) (fieldPath, fieldFlags, error) {
+	resolved := make(fieldPath, len(path))
+	copy(resolved, path)
+	flags := fieldFlags{}
+
+	current := root
+	for i, segment := range path {
+		for current.Kind() == reflect.Pointer {
+			current = current.Elem()
+		}
+
+		if segment.isIndex {
+			switch current.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				current = current.Elem()
+				continue
+			}
+			break
+		}
+		if current.Kind() != reflect.Struct {
+			break
+		}
+
+		info := typeInfoFor(current)
+		name := segment.field
+		if info.field(name) == nil {
+			if real, ok := info.aliases[name]; ok {
+				name = real
+				resolved[i].field = real
+			}
+		}
+
+		fi := info.field(name)
+		if fi == nil {
+			break
+		}
+		if fi.tagErr != nil {
+			return nil, fieldFlags{}, fi.tagErr
+		}
+		flags.ignore = flags.ignore || fi.ignore
+		flags.readonly = flags.readonly || fi.readonly
+		flags.noHistory = flags.noHistory || fi.noHistory
+
+		field, _ := current.FieldByName(name)
+		current = field.Type
+	}
+
+	return resolved, flags, nil
+}
+
+// field Настройки поля по имени (nil для неизвестного)
+func (t *typeInfo) field(name string) *fieldInfo {
+	return t.fields[name]
+}
+
+// parentStruct Тип структуры, содержащей последнее поле пути, и имя этого поля.
+// ok = false, если путь заканчивается индексом или проходит через значение, тип которого
+// известен только во время выполнения (interface{}): как и в resolvePath, такой путь не разбирается
+func parentStruct(root reflect.Type, path fieldPath) (reflect.Type, string, bool) {
+	current := root
+	for i, segment := range path {
+		for current.Kind() == reflect.Pointer {
+			current = current.Elem()
+		}
+
+		if i == len(path)-1 {
+			if segment.isIndex || current.Kind() != reflect.Struct {
+				return nil, "", false
+			}
+			return current, segment.field, true
+		}
+
+		if segment.isIndex {
+			switch current.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				current = current.Elem()
+				continue
+			}
+			return nil, "", false
+		}
+		if current.Kind() != reflect.Struct {
+			return nil, "", false
+		}
+		field, exists := current.FieldByName(segment.field)
+		if !exists {
+			return nil, "", false
+		}
+		current = field.Type
+	}
+	return nil, "", false
+}
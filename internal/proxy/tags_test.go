@@ -0,0 +1,109 @@
+package proxy
+
+import (
+	"Guess/internal/reactivity"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testTagged struct {
+	AllocMB string     `reactive:"name=alloc"`
+	Cache   string     `reactive:"-"`
+	Version string     `reactive:"readonly"`
+	Cursor  int        `reactive:"nohistory"`
+	Memory  testMemory `reactive:"readonly"`
+}
+
+// TestReactiveTags проверяем настройки полей из тега reactive
+func TestReactiveTags(t *testing.T) {
+	rt := reactivity.NewRuntime()
+	state := &testTagged{Version: "1.0"}
+	proxy := NewReactiveProxyIn(rt, state)
+
+	// Псевдоним работает в Get, Set и Watch, наблюдатель получает имя поля
+	var watched []string
+	proxy.Watch("alloc", "Set", func(fieldName string, oldValue, newValue interface{}) {
+		watched = append(watched, fieldName)
+	})
+	if err := proxy.Set("alloc", "1.00 MB"); err != nil {
+		t.Fatalf("Неожиданная ошибка %v", err)
+	}
+	if state.AllocMB != "1.00 MB" || proxy.Get("alloc") != "1.00 MB" {
+		t.Errorf("Псевдоним должен вести к AllocMB, получили %q", state.AllocMB)
+	}
+	if len(watched) != 1 || watched[0] != "AllocMB" {
+		t.Errorf("Ожидали вызов наблюдателя AllocMB, получили %v", watched)
+	}
+
+	// readonly действует и на вложенные пути
+	if err := proxy.Set("Version", "2.0"); !errors.Is(err, ErrReadonly) {
+		t.Errorf("Ожидали ErrReadonly, получили %v", err)
+	}
+	if err := proxy.Set("Memory.Heap", 1); !errors.Is(err, ErrReadonly) {
+		t.Errorf("Ожидали ErrReadonly для вложенного поля, получили %v", err)
+	}
+
+	// nohistory не попадает в историю
+	proxy.ClearHistory()
+	proxy.Set("Cursor", 5)
+	if len(proxy.GetHistory()) != 0 || state.Cursor != 5 {
+		t.Errorf("Cursor должен меняться без истории, история: %v", proxy.GetHistory())
+	}
+
+	// "-" не подписывает эффекты и не будит их
+	runs := 0
+	rt.WatchEffect(func() {
+		proxy.Get("Cache")
+		runs++
+	})
+	proxy.Set("Cache", "warm")
+	if runs != 1 || state.Cache != "warm" {
+		t.Errorf("Поле с reactive:\"-\" не должно перезапускать эффекты, запусков: %d", runs)
+	}
+	if len(proxy.GetHistory()) != 0 {
+		t.Errorf("Поле с reactive:\"-\" не должно попадать в историю")
+	}
+}
+
+// TestReactiveTagErrors проверяем ошибку в теге и кеширование разбора по типу
+func TestReactiveTagErrors(t *testing.T) {
+	type broken struct {
+		Name string `reactive:"sometimes"`
+	}
+	proxy := NewReactiveProxy(&broken{})
+
+	if err := proxy.Set("Name", "x"); !errors.Is(err, ErrInvalidReactiveTag) {
+		t.Errorf("Ожидали ErrInvalidReactiveTag, получили %v", err)
+	}
+
+	first := typeInfoFor(reflect.TypeFor[testTagged]())
+	if typeInfoFor(reflect.TypeFor[testTagged]()) != first {
+		t.Error("Разбор тегов должен кешироваться по типу")
+	}
+}
+
+// TestTagsThroughInterface проверяем путь через interface{}: настройки полей за ним не ищутся, Set не паникует
+func TestTagsThroughInterface(t *testing.T) {
+	type inner2 struct {
+		Y int
+	}
+	type inner struct {
+		X inner2
+	}
+	type holder struct {
+		Data interface{}
+	}
+	value := &inner{X: inner2{Y: 1}}
+	proxy := NewReactiveProxy(&holder{Data: value})
+
+	if err := proxy.Set("Data.X.Y", 2); err != nil {
+		t.Fatalf("Неожиданная ошибка %v", err)
+	}
+	if value.X.Y != 2 || proxy.Get("Data.X.Y") != 2 {
+		t.Errorf("Ожидали 2, получили %d", value.X.Y)
+	}
+	if _, _, ok := parentStruct(reflect.TypeFor[holder](), fieldPath{{field: "Data"}, {field: "X"}, {field: "Y"}}); ok {
+		t.Error("parentStruct не должен разбирать путь через interface{}")
+	}
+}
@@ -0,0 +1,199 @@
+package proxy
+
+import (
+	"Guess/internal/reactivity"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Proxy Типизированный реактивный Proxy над *T.
+// В отличие от ReactiveProxy, Get тоже сообщает об ошибках доступа
+type Proxy[T any] struct {
+	*ReactiveProxy
+	convert bool
+}
+
+// ProxyOption Настройка Proxy
+type ProxyOption func(config *proxyConfig)
+
+type proxyConfig struct {
+	convert  bool
+	readonly bool
+	shallow  bool
+	runtime  *reactivity.Runtime
+	history  int
+}
+
+// WithHistorySize Задать длину истории изменений (0 и меньше — без ограничения)
+func WithHistorySize(size int) ProxyOption {
+	return func(config *proxyConfig) {
+		config.history = size
+	}
+}
+
+// WithRuntime Создать Proxy в заданной среде реактивности
+func WithRuntime(rt *reactivity.Runtime) ProxyOption {
+	return func(config *proxyConfig) {
+		config.runtime = rt
+	}
+}
+
+// WithConversion Разрешить Set преобразовывать совместимые типы:
+// числа без потери значения (int -> int64) и именованные типы с общей основой (string -> Status)
+func WithConversion() ProxyOption {
+	return func(config *proxyConfig) {
+		config.convert = true
+	}
+}
+
+// AsReadonly Создать Proxy только для чтения
+func AsReadonly() ProxyOption {
+	return func(config *proxyConfig) {
+		config.readonly = true
+	}
+}
+
+// AsShallow Сравнивать значения по == без обхода вложенных структур
+func AsShallow() ProxyOption {
+	return func(config *proxyConfig) {
+		config.shallow = true
+	}
+}
+
+// NewProxy Конструктор. T должен быть структурой
+func NewProxy[T any](target *T, opts ...ProxyOption) *Proxy[T] {
+	targetType := reflect.TypeFor[T]()
+	if targetType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("proxy: Proxy ожидает структуру, получено %s", targetType))
+	}
+
+	config := proxyConfig{runtime: reactivity.DefaultRuntime(), history: DefaultHistorySize}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	p := NewReactiveProxyIn(config.runtime, target)
+	p.readonly = config.readonly
+	p.shallow = config.shallow
+	p.historySize = config.history
+
+	return &Proxy[T]{
+		ReactiveProxy: p,
+		convert:       config.convert,
+	}
+}
+
+// Original Получить исходную структуру
+func (p *Proxy[T]) Original() *T {
+	return p.target.(*T)
+}
+
+// Get Получить значение поля
+func (p *Proxy[T]) Get(fieldName string) (interface{}, error) {
+	return p.get(fieldName)
+}
+
+// Set Установить значение поля
+func (p *Proxy[T]) Set(fieldName string, newValue interface{}) error {
+	return p.set(fieldName, newValue, p.convert)
+}
+
+// Readonly Представление только для чтения над тем же объектом
+func (p *Proxy[T]) Readonly() *Proxy[T] {
+	return &Proxy[T]{
+		ReactiveProxy: p.ReactiveProxy.Readonly(),
+		convert:       p.convert,
+	}
+}
+
+// assignableValue Подготовить значение для присваивания полю типа to
+func assignableValue(value interface{}, to reflect.Type, convert bool) (reflect.Value, error) {
+	if value == nil {
+		switch to.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+			return reflect.Zero(to), nil
+		}
+		return reflect.Value{}, fmt.Errorf("%w: nil нельзя присвоить %s", ErrTypeMismatch, to)
+	}
+
+	from := reflect.ValueOf(value)
+	if from.Type().AssignableTo(to) {
+		// Приводим к типу поля, чтобы сравнение со старым значением не зависело от типа аргумента
+		assigned := reflect.New(to).Elem()
+		assigned.Set(from)
+		return assigned, nil
+	}
+
+	if convert {
+		if converted, ok := convertValue(from, to); ok {
+			return converted, nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("%w: %s нельзя присвоить %s", ErrTypeMismatch, from.Type(), to)
+}
+
+// convertValue Преобразовать значение без потери: между типами одного вида
+// (string -> именованная строка) и между числами, если значение помещается в тип поля.
+// Преобразования вроде int -> string, которые Go разрешает, но меняют смысл, запрещены
+func convertValue(from reflect.Value, to reflect.Type) (reflect.Value, bool) {
+	if !from.Type().ConvertibleTo(to) {
+		return reflect.Value{}, false
+	}
+
+	switch {
+	case from.Kind() == to.Kind() && !isNumber(from.Kind()):
+		return from.Convert(to), true
+
+	case isInt(from.Kind()) && isInt(to.Kind()):
+		if reflect.Zero(to).OverflowInt(from.Int()) {
+			return reflect.Value{}, false
+		}
+	case isUint(from.Kind()) && isUint(to.Kind()):
+		if reflect.Zero(to).OverflowUint(from.Uint()) {
+			return reflect.Value{}, false
+		}
+	case isInt(from.Kind()) && isUint(to.Kind()):
+		if from.Int() < 0 || reflect.Zero(to).OverflowUint(uint64(from.Int())) {
+			return reflect.Value{}, false
+		}
+	case isUint(from.Kind()) && isInt(to.Kind()):
+		if from.Uint() > math.MaxInt64 || reflect.Zero(to).OverflowInt(int64(from.Uint())) {
+			return reflect.Value{}, false
+		}
+	case (isInt(from.Kind()) || isUint(from.Kind()) || isFloat(from.Kind())) && isFloat(to.Kind()):
+		var number float64
+		switch {
+		case isInt(from.Kind()):
+			number = float64(from.Int())
+		case isUint(from.Kind()):
+			number = float64(from.Uint())
+		default:
+			number = from.Float()
+		}
+		if reflect.Zero(to).OverflowFloat(number) {
+			return reflect.Value{}, false
+		}
+	default:
+		return reflect.Value{}, false
+	}
+
+	return from.Convert(to), true
+}
+
+func isInt(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUint(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return isInt(kind) || isUint(kind) || isFloat(kind) || kind == reflect.Complex64 || kind == reflect.Complex128
+}
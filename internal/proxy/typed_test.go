@@ -0,0 +1,108 @@
+package proxy
+
+import (
+	"errors"
+	"testing"
+)
+
+type testStatus string
+
+type testServer struct {
+	Host    string
+	Port    int64
+	Status  testStatus
+	Weight  float64
+	Tags    []string
+	private string
+}
+
+// TestProxyGetErrors проверяем ошибки чтения
+func TestProxyGetErrors(t *testing.T) {
+	proxy := NewProxy(&testServer{Host: "localhost", private: "секрет"})
+
+	host, err := proxy.Get("Host")
+	if err != nil || host != "localhost" {
+		t.Errorf("Ожидали localhost, получили %v (%v)", host, err)
+	}
+
+	if _, err := proxy.Get("Missing"); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Ожидали ErrUnknownField, получили %v", err)
+	}
+	if _, err := proxy.Get("private"); !errors.Is(err, ErrUnexportedField) {
+		t.Errorf("Ожидали ErrUnexportedField, получили %v", err)
+	}
+}
+
+// TestProxySetErrors проверяем, что Set сообщает об ошибках вместо паники
+func TestProxySetErrors(t *testing.T) {
+	server := &testServer{Port: 80}
+	proxy := NewProxy(server)
+
+	tests := []struct {
+		name  string
+		field string
+		value interface{}
+		want  error
+	}{
+		{"неизвестное поле", "Missing", 1, ErrUnknownField},
+		{"неэкспортируемое поле", "private", "x", ErrUnexportedField},
+		{"чужой тип", "Port", "8080", ErrTypeMismatch},
+		{"int без преобразования", "Port", 8080, ErrTypeMismatch},
+		{"nil в число", "Port", nil, ErrTypeMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := proxy.Set(tt.field, tt.value); !errors.Is(err, tt.want) {
+				t.Errorf("Ожидали %v, получили %v", tt.want, err)
+			}
+		})
+	}
+
+	if server.Port != 80 {
+		t.Errorf("Ошибочный Set не должен менять структуру, Port = %d", server.Port)
+	}
+	if err := proxy.Set("Tags", nil); err != nil {
+		t.Errorf("nil должен присваиваться срезу, получили %v", err)
+	}
+}
+
+// TestProxyConversion проверяем преобразование совместимых типов
+func TestProxyConversion(t *testing.T) {
+	server := &testServer{}
+	proxy := NewProxy(server, WithConversion())
+
+	if err := proxy.Set("Port", 8080); err != nil {
+		t.Errorf("int должен преобразовываться в int64, получили %v", err)
+	}
+	if err := proxy.Set("Status", "online"); err != nil {
+		t.Errorf("string должен преобразовываться в testStatus, получили %v", err)
+	}
+	if err := proxy.Set("Weight", 3); err != nil {
+		t.Errorf("int должен преобразовываться в float64, получили %v", err)
+	}
+
+	if server.Port != 8080 || server.Status != "online" || server.Weight != 3 {
+		t.Errorf("Значения не установлены: %+v", server)
+	}
+
+	if err := proxy.Set("Host", 42); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("int не должен превращаться в строку, получили %v", err)
+	}
+	if err := proxy.Set("Port", 1.5); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("float не должен обрезаться до int64, получили %v", err)
+	}
+}
+
+// TestProxyOriginal проверяем типизированный доступ к структуре и readonly-представление
+func TestProxyOriginal(t *testing.T) {
+	server := &testServer{Host: "localhost"}
+	proxy := NewProxy(server)
+
+	if proxy.Original() != server {
+		t.Error("Original должен возвращать исходную структуру")
+	}
+	if err := proxy.Readonly().Set("Host", "example.com"); !errors.Is(err, ErrReadonly) {
+		t.Errorf("Ожидали ErrReadonly, получили %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Ошибки проверки значений
+var (
+	// ErrValidation Значение нарушает правило из тега validate
+	ErrValidation = errors.New("значение не прошло проверку")
+	// ErrInvalidTag Тег validate не разбирается
+	ErrInvalidTag = errors.New("некорректный тег validate")
+)
+
+// validationRule Одно правило тега validate:"required,min=0,max=100,oneof=a b,pattern=^[a-z]+$".
+// min, max и len для чисел сравнивают значение, для строк, срезов и map — длину.
+// Значение pattern не может содержать запятых
+type validationRule struct {
+	name    string
+	arg     string
+	number  float64
+	options []string
+	pattern *regexp.Regexp
+}
+
+// parseRules Разобрать тег validate
+func parseRules(tag string) ([]validationRule, error) {
+	rules := make([]validationRule, 0)
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, arg, _ := strings.Cut(part, "=")
+		rule := validationRule{name: name, arg: arg}
+
+		var err error
+		switch name {
+		case "required":
+		case "min", "max", "len":
+			rule.number, err = strconv.ParseFloat(arg, 64)
+		case "oneof":
+			rule.options = strings.Fields(arg)
+		case "pattern":
+			rule.pattern, err = regexp.Compile(arg)
+		default:
+			err = fmt.Errorf("неизвестное правило %q", name)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidTag, part, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// check Проверить значение, вернуть описание нарушения или пустую строку
+func (r validationRule) check(value reflect.Value) string {
+	switch r.name {
+	case "required":
+		if value.IsZero() {
+			return "значение обязательно"
+		}
+	case "min", "max", "len":
+		measure, what, ok := measureValue(value)
+		if !ok {
+			return ""
+		}
+		switch {
+		case r.name == "min" && measure < r.number:
+			return fmt.Sprintf("%s %v меньше %s", what, measure, r.arg)
+		case r.name == "max" && measure > r.number:
+			return fmt.Sprintf("%s %v больше %s", what, measure, r.arg)
+		case r.name == "len" && measure != r.number:
+			return fmt.Sprintf("%s %v не равна %s", what, measure, r.arg)
+		}
+	case "oneof":
+		text := fmt.Sprint(value.Interface())
+		for _, option := range r.options {
+			if option == text {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%q не из списка %s", text, strings.Join(r.options, ", "))
+	case "pattern":
+		if value.Kind() == reflect.String && !r.pattern.MatchString(value.String()) {
+			return fmt.Sprintf("%q не соответствует шаблону %s", value.String(), r.arg)
+		}
+	}
+	return ""
+}
+
+// measureValue Число для min/max/len: само значение для чисел и длина для остального
+func measureValue(value reflect.Value) (float64, string, bool) {
+	switch {
+	case isInt(value.Kind()):
+		return float64(value.Int()), "значение", true
+	case isUint(value.Kind()):
+		return float64(value.Uint()), "значение", true
+	case isFloat(value.Kind()):
+		return value.Float(), "значение", true
+	case value.Kind() == reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), "длина", true
+	case value.Kind() == reflect.Slice || value.Kind() == reflect.Map || value.Kind() == reflect.Array:
+		return float64(value.Len()), "длина", true
+	}
+	return 0, "", false
+}
+
+// validatePath Проверить новое значение по тегам поля, в которое оно пишется,
+// и по тегам вложенных полей, если записывается структура целиком
+func validatePath(root reflect.Type, path fieldPath, value reflect.Value) error {
+	if parent, name, ok := parentStruct(root, path); ok {
+		if field := typeInfoFor(parent).field(name); field != nil {
+			if err := validateField(field, value); err != nil {
+				return err
+			}
+		}
+	}
+	return validateNested(value)
+}
+
+func validateField(field *fieldInfo, value reflect.Value) error {
+	if field.ruleErr != nil {
+		return field.ruleErr
+	}
+	for _, rule := range field.rules {
+		if problem := rule.check(value); problem != "" {
+			return fmt.Errorf("%w: %s", ErrValidation, problem)
+		}
+	}
+	return nil
+}
+
+// validateNested Проверить поля вложенной структуры по их тегам
+func validateNested(value reflect.Value) error {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	info := typeInfoFor(value.Type())
+	for _, structField := range reflect.VisibleFields(value.Type()) {
+		field := info.field(structField.Name)
+		if field == nil {
+			continue
+		}
+
+		fieldValue, err := value.FieldByIndexErr(structField.Index)
+		if err != nil {
+			continue
+		}
+		if err := validateField(field, fieldValue); err != nil {
+			return fmt.Errorf("%s: %w", structField.Name, err)
+		}
+		// По указателям не спускаемся: данные могут ссылаться сами на себя
+		if fieldValue.Kind() != reflect.Struct {
+			continue
+		}
+		if err := validateNested(fieldValue); err != nil {
+			return fmt.Errorf("%s.%w", structField.Name, err)
+		}
+	}
+	return nil
+}
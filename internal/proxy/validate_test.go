@@ -0,0 +1,108 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type testLimits struct {
+	Percent int `validate:"min=0,max=100"`
+}
+
+type testForm struct {
+	Count  int      `validate:"min=0"`
+	Email  string   `validate:"required,pattern=^[^@]+@[^@]+$"`
+	Level  string   `validate:"oneof=debug info error"`
+	Tags   []string `validate:"max=2"`
+	Limits testLimits
+	Broken string `validate:"min=abc"`
+}
+
+// TestValidateTags проверяем декларативные проверки из тегов
+func TestValidateTags(t *testing.T) {
+	form := &testForm{Email: "a@b.c", Level: "info"}
+	proxy := NewReactiveProxy(form)
+
+	tests := []struct {
+		path  string
+		value interface{}
+		want  error
+	}{
+		{"Count", -1, ErrValidation},
+		{"Count", 5, nil},
+		{"Email", "", ErrValidation},
+		{"Email", "broken", ErrValidation},
+		{"Level", "trace", ErrValidation},
+		{"Level", "error", nil},
+		{"Tags", []string{"a", "b", "c"}, ErrValidation},
+		{"Limits.Percent", 101, ErrValidation},
+		{"Limits", testLimits{Percent: -5}, ErrValidation},
+		{"Limits", testLimits{Percent: 50}, nil},
+		{"Broken", "x", ErrInvalidTag},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s=%v", tt.path, tt.value), func(t *testing.T) {
+			err := proxy.Set(tt.path, tt.value)
+			if tt.want == nil && err != nil {
+				t.Errorf("Неожиданная ошибка %v", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Ожидали %v, получили %v", tt.want, err)
+			}
+		})
+	}
+
+	if form.Count != 5 || form.Level != "error" || form.Limits.Percent != 50 {
+		t.Errorf("Отклонённые значения не должны попадать в структуру: %+v", form)
+	}
+}
+
+// TestIntercept проверяем цепочку обработчиков: преобразование, приведение и запрет
+func TestIntercept(t *testing.T) {
+	form := &testForm{Email: "a@b.c", Level: "info"}
+	proxy := NewReactiveProxy(form)
+
+	// Приведение: обрезаем пробелы и приводим к нижнему регистру
+	proxy.Intercept(func(fieldName string, oldValue, newValue interface{}) (interface{}, error) {
+		if text, ok := newValue.(string); ok {
+			return strings.ToLower(strings.TrimSpace(text)), nil
+		}
+		return newValue, nil
+	})
+	// Запрет: счётчик нельзя уменьшать
+	errDecrease := errors.New("счётчик нельзя уменьшать")
+	proxy.Intercept(func(fieldName string, oldValue, newValue interface{}) (interface{}, error) {
+		if fieldName == "Count" && newValue.(int) < oldValue.(int) {
+			return nil, errDecrease
+		}
+		return newValue, nil
+	})
+
+	watched := 0
+	proxy.Watch("Level", "Set", func(fieldName string, oldValue, newValue interface{}) {
+		watched++
+	})
+
+	if err := proxy.Set("Level", "  DEBUG "); err != nil {
+		t.Fatalf("Неожиданная ошибка %v", err)
+	}
+	if form.Level != "debug" || watched != 1 {
+		t.Errorf("Ожидали приведённое значение debug, получили %q", form.Level)
+	}
+
+	proxy.Set("Count", 3)
+	if err := proxy.Set("Count", 2); !errors.Is(err, errDecrease) {
+		t.Errorf("Ожидали запрет, получили %v", err)
+	}
+	if form.Count != 3 {
+		t.Errorf("Запрещённое значение не должно записываться, получили %d", form.Count)
+	}
+
+	// Проверки тегов выполняются после цепочки
+	if err := proxy.Set("Level", " TRACE"); !errors.Is(err, ErrValidation) {
+		t.Errorf("Ожидали ErrValidation, получили %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package reactivity
+
+import (
+	"context"
+)
+
+// asyncRun Один запуск асинхронного эффекта в отдельной горутине
+type asyncRun struct {
+	effect *ReactiveEffect
+	ctx    context.Context
+}
+
+// asyncRunKey Ключ, под которым запуск лежит в ctx асинхронного эффекта
+type asyncRunKey struct{}
+
+// WatchEffectAsync Асинхронный эффект в среде по умолчанию
+func WatchEffectAsync(fn func(ctx context.Context), opts ...EffectOption) *ReactiveEffect {
+	return defaultRuntime.WatchEffectAsync(fn, opts...)
+}
+
+// WatchEffectAsync Эффект, тело которого выполняется в отдельной горутине и не блокирует
+// горутину, вызвавшую Trigger. Тело идёт вне владельца цикла, поэтому зависимости
+// отмечаются явно: чтения внутри Tracked(ctx, ...) подписывают эффект.
+// Каждый перезапуск и Stop отменяют ctx предыдущего запуска
+func (rt *Runtime) WatchEffectAsync(fn func(ctx context.Context), opts ...EffectOption) *ReactiveEffect {
+	var cancel context.CancelFunc
+
+	var effect *ReactiveEffect
+	effect = rt.NewReactiveEffect(func() {
+		if cancel != nil {
+			cancel()
+		}
+
+		ctx, cancelRun := context.WithCancel(context.Background())
+		cancel = cancelRun
+		run := &asyncRun{effect: effect}
+		run.ctx = context.WithValue(ctx, asyncRunKey{}, run)
+
+		go rt.runAsync(run, fn)
+	}, opts...)
+
+	rt.mu.Lock()
+	effect.OnStop = append(effect.OnStop, func() {
+		if cancel != nil {
+			cancel()
+		}
+	})
+	rt.mu.Unlock()
+
+	effect.Run()
+	return effect
+}
+
+// runAsync Выполнить запуск асинхронного эффекта в текущей горутине
+func (rt *Runtime) runAsync(run *asyncRun, fn func(ctx context.Context)) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			rt.reportPanic(run.effect, recovered)
+		}
+	}()
+
+	fn(run.ctx)
+}
+
+// Tracked Выполнить fn от имени асинхронного эффекта, чей ctx передан: чтения внутри fn
+// подписывают эффект, OnCleanup регистрирует обработчик в нём. Активный эффект — состояние
+// владельца цикла, поэтому fn выполняется им (см. owner.go) и должна быть короткой:
+// пока она идёт, остальные эффекты ждут. После отмены запуска fn выполняется без
+// отслеживания, вне асинхронного эффекта — как есть
+func Tracked(ctx context.Context, fn func()) {
+	run, _ := ctx.Value(asyncRunKey{}).(*asyncRun)
+	if run == nil {
+		fn()
+		return
+	}
+
+	rt := run.effect.runtime
+	runOwned(func() {
+		rt.mu.Lock()
+		if ctx.Err() != nil || !run.effect.Active {
+			rt.mu.Unlock()
+			rt.Untracked(fn)
+			return
+		}
+		rt.pushFrameLocked(run.effect)
+		rt.mu.Unlock()
+
+		defer func() {
+			rt.mu.Lock()
+			rt.popFrameLocked()
+			rt.unlock()
+		}()
+
+		ownerCall(fn)
+	})
+}
+
+// TrackedValue Прочитать значение от имени асинхронного эффекта, например TrackedValue(ctx, path.Get)
+func TrackedValue[T any](ctx context.Context, read func() T) T {
+	var value T
+	Tracked(ctx, func() {
+		value = read()
+	})
+	return value
+}
+
+// OnCleanup Зарегистрировать обработчик в текущем эффекте среды по умолчанию
+func OnCleanup(fn func()) {
+	defaultRuntime.OnCleanup(fn)
+}
+
+// OnCleanup Зарегистрировать обработчик, который вызовется перед следующим запуском
+// текущего эффекта и при его остановке. В асинхронном эффекте вызывается внутри Tracked
+func (rt *Runtime) OnCleanup(fn func()) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if effect := rt.currentEffectLocked(); effect != nil {
+		effect.addCleanup(fn)
+	}
+}
@@ -0,0 +1,238 @@
+package reactivity
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestWatchEffectAsync проверяем, что перезапуск отменяет контекст предыдущего запуска
+func TestWatchEffectAsync(t *testing.T) {
+	rt := NewRuntime()
+	path := NewRefIn(rt, "/proc/meminfo")
+
+	started := make(chan string, 4)
+	cancelled := make(chan string, 4)
+
+	rt.WatchEffectAsync(func(ctx context.Context) {
+		current := TrackedValue(ctx, path.Get)
+		started <- current
+
+		// Имитация долгого чтения, которое прерывается перезапуском
+		<-ctx.Done()
+		cancelled <- current
+	})
+
+	if got := waitString(t, started); got != "/proc/meminfo" {
+		t.Fatalf("Ожидали первый запуск с /proc/meminfo, получили %s", got)
+	}
+
+	// Set не ждёт завершения асинхронного тела
+	path.Set("/proc/stat")
+
+	if got := waitString(t, cancelled); got != "/proc/meminfo" {
+		t.Errorf("Перезапуск должен отменить первый запуск, отменён %s", got)
+	}
+	if got := waitString(t, started); got != "/proc/stat" {
+		t.Errorf("Ожидали второй запуск с /proc/stat, получили %s", got)
+	}
+}
+
+// TestTrackedCancelled проверяем, что чтения отменённого запуска не подписывают эффект
+func TestTrackedCancelled(t *testing.T) {
+	rt := NewRuntime()
+	first := NewRefIn(rt, 0)
+	stale := NewRefIn(rt, 0)
+	runs := make(chan context.Context, 4)
+
+	rt.WatchEffectAsync(func(ctx context.Context) {
+		Tracked(ctx, func() {
+			first.Get()
+		})
+		runs <- ctx
+	})
+
+	firstCtx := waitContext(t, runs)
+	first.Set(1)
+	waitContext(t, runs)
+
+	if firstCtx.Err() == nil {
+		t.Fatal("Перезапуск должен отменить контекст первого запуска")
+	}
+
+	// Запоздалое чтение первого запуска не должно подписать эффект на stale
+	Tracked(firstCtx, func() {
+		stale.Get()
+	})
+	stale.Set(1)
+
+	select {
+	case <-runs:
+		t.Error("Чтение отменённого запуска не должно перезапускать эффект")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestWatchEffectAsyncStop проверяем отмену контекста при остановке
+func TestWatchEffectAsyncStop(t *testing.T) {
+	rt := NewRuntime()
+	cancelled := make(chan string, 1)
+	started := make(chan string, 1)
+
+	effect := rt.WatchEffectAsync(func(ctx context.Context) {
+		started <- "started"
+		<-ctx.Done()
+		cancelled <- "cancelled"
+	})
+
+	waitString(t, started)
+	effect.Stop()
+	waitString(t, cancelled)
+}
+
+// TestOnCleanup проверяем обработчики перед перезапуском и при остановке
+func TestOnCleanup(t *testing.T) {
+	rt := NewRuntime()
+	count := NewRefIn(rt, 0)
+	var log []string
+
+	effect := rt.WatchEffect(func() {
+		value := count.Get()
+		rt.OnCleanup(func() {
+			log = append(log, "cleanup", string(rune('0'+value)))
+		})
+	})
+
+	count.Set(1)
+	effect.Stop()
+	effect.Stop()
+
+	want := []string{"cleanup", "0", "cleanup", "1"}
+	if len(log) != len(want) {
+		t.Fatalf("Ожидали %v, получили %v", want, log)
+	}
+	for i := range want {
+		if log[i] != want[i] {
+			t.Errorf("Ожидали %v, получили %v", want, log)
+			break
+		}
+	}
+}
+
+// TestOnCleanupAsync проверяем OnCleanup внутри асинхронного эффекта
+func TestOnCleanupAsync(t *testing.T) {
+	rt := NewRuntime()
+	count := NewRefIn(rt, 0)
+	var cleaned atomic.Int32
+	registered := make(chan string, 4)
+
+	rt.WatchEffectAsync(func(ctx context.Context) {
+		Tracked(ctx, func() {
+			count.Get()
+			rt.OnCleanup(func() {
+				cleaned.Add(1)
+			})
+		})
+		registered <- "registered"
+	})
+
+	waitString(t, registered)
+	count.Set(1)
+	waitString(t, registered)
+
+	if cleaned.Load() != 1 {
+		t.Errorf("Ожидали 1 вызов обработчика, получили %d", cleaned.Load())
+	}
+}
+
+// TestTrackedDuringSyncEffect проверяем синхронный и асинхронный эффекты одновременно:
+// каждый подписывается на свои чтения, даже если синхронный запущен во время Tracked
+func TestTrackedDuringSyncEffect(t *testing.T) {
+	rt := NewRuntime()
+	asyncSource := NewRefIn(rt, 0)
+	syncSource := NewRefIn(rt, 0)
+
+	inTracked := make(chan struct{})
+	release := make(chan struct{})
+	asyncRuns := make(chan string, 4)
+	var blocked atomic.Bool
+	rt.WatchEffectAsync(func(ctx context.Context) {
+		Tracked(ctx, func() {
+			if blocked.CompareAndSwap(false, true) {
+				close(inTracked)
+				<-release
+			}
+			asyncSource.Get()
+		})
+		asyncRuns <- "run"
+	})
+	<-inTracked
+
+	syncIn := make(chan struct{}, 1)
+	syncRelease := make(chan struct{})
+	syncDone := make(chan struct{})
+	var syncRuns atomic.Int32
+	go func() {
+		defer close(syncDone)
+		rt.WatchEffect(func() {
+			syncSource.Get()
+			syncRuns.Add(1)
+			select {
+			case syncIn <- struct{}{}:
+			default:
+			}
+			<-syncRelease
+		})
+	}()
+
+	// Синхронный эффект либо уже идёт, либо ждёт конца Tracked
+	select {
+	case <-syncIn:
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	waitString(t, asyncRuns)
+	close(syncRelease)
+	<-syncDone
+
+	syncSource.Set(1)
+	if syncRuns.Load() != 2 {
+		t.Errorf("Синхронный эффект должен перезапуститься от своего чтения, запусков %d", syncRuns.Load())
+	}
+	select {
+	case <-asyncRuns:
+		t.Error("Чтение синхронного эффекта не должно перезапускать асинхронный")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	asyncSource.Set(1)
+	waitString(t, asyncRuns)
+	if syncRuns.Load() != 2 {
+		t.Errorf("Чтение асинхронного эффекта не должно перезапускать синхронный, запусков %d", syncRuns.Load())
+	}
+}
+
+func waitString(t *testing.T, ch <-chan string) string {
+	t.Helper()
+
+	select {
+	case value := <-ch:
+		return value
+	case <-time.After(2 * time.Second):
+		t.Fatal("Не дождались асинхронного эффекта")
+		return ""
+	}
+}
+
+func waitContext(t *testing.T, ch <-chan context.Context) context.Context {
+	t.Helper()
+
+	select {
+	case ctx := <-ch:
+		return ctx
+	case <-time.After(2 * time.Second):
+		t.Fatal("Не дождались асинхронного эффекта")
+		return nil
+	}
+}
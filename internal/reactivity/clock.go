@@ -0,0 +1,141 @@
+package reactivity
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock Источник времени для отложенных перезапусков. В тестах заменяется на FakeClock
+type Clock interface {
+	Now() time.Time
+	// AfterFunc Вызвать fn в отдельной горутине через d
+	AfterFunc(d time.Duration, fn func()) Timer
+}
+
+// Timer Отложенный вызов, который можно отменить
+type Timer interface {
+	// Stop Отменить вызов. false, если он уже выполнен или отменён
+	Stop() bool
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(d time.Duration, fn func()) Timer {
+	return time.AfterFunc(d, fn)
+}
+
+// SystemClock Настоящие часы (по умолчанию)
+var SystemClock Clock = systemClock{}
+
+// SetClock Задать часы среды (nil — SystemClock)
+func (rt *Runtime) SetClock(clock Clock) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if clock == nil {
+		clock = SystemClock
+	}
+	rt.clock = clock
+}
+
+// Clock Часы среды
+func (rt *Runtime) Clock() Clock {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	return rt.clock
+}
+
+// FakeClock Ручные часы для тестов: время идёт только при вызове Advance,
+// отложенные вызовы выполняются синхронно в горутине Advance
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	at    time.Time
+	fn    func()
+}
+
+// NewFakeClock Конструктор
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		now:    now,
+		timers: make([]*fakeTimer, 0),
+	}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *FakeClock) AfterFunc(d time.Duration, fn func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer := &fakeTimer{clock: c, at: c.now.Add(d), fn: fn}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+// Advance Перевести часы на d вперёд, выполнив по порядку все наступившие вызовы,
+// в том числе запланированные самими этими вызовами
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+
+	for {
+		next := -1
+		for i, timer := range c.timers {
+			if !timer.at.After(target) && (next < 0 || timer.at.Before(c.timers[next].at)) {
+				next = i
+			}
+		}
+
+		if next < 0 {
+			c.now = target
+			c.mu.Unlock()
+			return
+		}
+
+		timer := c.timers[next]
+		c.timers = append(c.timers[:next], c.timers[next+1:]...)
+		c.now = timer.at
+
+		c.mu.Unlock()
+		timer.fn()
+		c.mu.Lock()
+	}
+}
+
+// Pending Количество ещё не выполненных вызовов
+func (c *FakeClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.timers)
+}
+
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, timer := range c.timers {
+		if timer == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
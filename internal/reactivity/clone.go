@@ -0,0 +1,94 @@
+package reactivity
+
+import (
+	"reflect"
+)
+
+// deepCopy Глубокая копия значения для сравнения «до» и «после».
+// Неэкспортируемые поля копируются поверхностно. Общие указатели, map и срезы
+// копируются один раз, поэтому циклы (например, ссылки Parent в дереве) не зацикливают копирование
+func deepCopy[T any](value T) T {
+	original := reflect.ValueOf(&value).Elem()
+	copied := reflect.New(original.Type()).Elem()
+	copyValue(copied, original, make(map[visit]reflect.Value))
+	return copied.Interface().(T)
+}
+
+// visit Уже скопированное ссылочное значение, как в reflect.DeepEqual: адрес и тип,
+// для срезов ещё и длина
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+func copyValue(dst, src reflect.Value, visited map[visit]reflect.Value) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		key := visit{ptr: src.Pointer(), typ: src.Type()}
+		if copied, ok := visited[key]; ok {
+			dst.Set(copied)
+			return
+		}
+		copied := reflect.New(src.Type().Elem())
+		visited[key] = copied
+		dst.Set(copied)
+		copyValue(copied.Elem(), src.Elem(), visited)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		inner := reflect.New(src.Elem().Type()).Elem()
+		copyValue(inner, src.Elem(), visited)
+		dst.Set(inner)
+	case reflect.Struct:
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				copyValue(dst.Field(i), src.Field(i), visited)
+			}
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		key := visit{ptr: src.Pointer(), typ: src.Type(), len: src.Len()}
+		if copied, ok := visited[key]; ok {
+			dst.Set(copied)
+			return
+		}
+		copied := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		visited[key] = copied
+		dst.Set(copied)
+		for i := 0; i < src.Len(); i++ {
+			copyValue(copied.Index(i), src.Index(i), visited)
+		}
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			copyValue(dst.Index(i), src.Index(i), visited)
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		key := visit{ptr: src.Pointer(), typ: src.Type()}
+		if copied, ok := visited[key]; ok {
+			dst.Set(copied)
+			return
+		}
+		copied := reflect.MakeMapWithSize(src.Type(), src.Len())
+		visited[key] = copied
+		dst.Set(copied)
+		iter := src.MapRange()
+		for iter.Next() {
+			item := reflect.New(src.Type().Elem()).Elem()
+			copyValue(item, iter.Value(), visited)
+			copied.SetMapIndex(iter.Key(), item)
+		}
+	default:
+		dst.Set(src)
+	}
+}
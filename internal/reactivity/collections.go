@@ -0,0 +1,243 @@
+package reactivity
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// Служебные ключи коллекций. Ключи map отслеживаются как "[key]", индексы среза — как числа,
+// поэтому со служебными ключами они не пересекаются
+const (
+	iterateKey = "<iterate>" // обход ключей и значений: Range, Values
+	keysKey    = "<keys>"    // состав ключей: Len, Keys
+	lengthKey  = "<length>"  // длина среза
+)
+
+// ReactiveMap Реактивная map с отслеживанием чтений по отдельным ключам.
+// Изменение значения будит только читателей этого ключа и обходов Range,
+// добавление и удаление ключа — ещё и читателей Len/Keys
+type ReactiveMap[K comparable, V any] struct {
+	runtime *Runtime
+	items   map[K]V
+}
+
+// NewReactiveMap Конструктор в среде по умолчанию
+func NewReactiveMap[K comparable, V any]() *ReactiveMap[K, V] {
+	return NewReactiveMapIn[K, V](defaultRuntime)
+}
+
+// NewReactiveMapIn Конструктор в заданной среде
+func NewReactiveMapIn[K comparable, V any](rt *Runtime) *ReactiveMap[K, V] {
+	return &ReactiveMap[K, V]{
+		runtime: rt,
+		items:   make(map[K]V),
+	}
+}
+
+func mapKey[K comparable](key K) string {
+	return fmt.Sprintf("[%v]", key)
+}
+
+// Get Получить значение по ключу
+func (m *ReactiveMap[K, V]) Get(key K) (V, bool) {
+	m.runtime.mu.Lock()
+	defer m.runtime.unlock()
+
+	m.runtime.trackLocked(m, mapKey(key))
+	value, exists := m.items[key]
+	return value, exists
+}
+
+// Has Есть ли ключ
+func (m *ReactiveMap[K, V]) Has(key K) bool {
+	m.runtime.mu.Lock()
+	defer m.runtime.unlock()
+
+	m.runtime.trackLocked(m, mapKey(key))
+	_, exists := m.items[key]
+	return exists
+}
+
+// Set Установить значение по ключу
+func (m *ReactiveMap[K, V]) Set(key K, value V) {
+	m.runtime.mu.Lock()
+	defer m.runtime.unlock()
+
+	oldValue, exists := m.items[key]
+	if exists && reflect.DeepEqual(oldValue, value) {
+		return
+	}
+
+	m.items[key] = value
+	if exists {
+		m.runtime.triggerLocked(m, TriggerSet, mapKey(key), iterateKey)
+	} else {
+		m.runtime.triggerLocked(m, TriggerAdd, mapKey(key), iterateKey, keysKey)
+	}
+}
+
+// Delete Удалить ключ
+func (m *ReactiveMap[K, V]) Delete(key K) {
+	m.runtime.mu.Lock()
+	defer m.runtime.unlock()
+
+	if _, exists := m.items[key]; !exists {
+		return
+	}
+
+	delete(m.items, key)
+	m.runtime.triggerLocked(m, TriggerDelete, mapKey(key), iterateKey, keysKey)
+}
+
+// Len Количество ключей. Подписывает только на добавление и удаление
+func (m *ReactiveMap[K, V]) Len() int {
+	m.runtime.mu.Lock()
+	defer m.runtime.unlock()
+
+	m.runtime.trackLocked(m, keysKey)
+	return len(m.items)
+}
+
+// Keys Ключи в произвольном порядке. Подписывает только на добавление и удаление
+func (m *ReactiveMap[K, V]) Keys() []K {
+	m.runtime.mu.Lock()
+	defer m.runtime.unlock()
+
+	m.runtime.trackLocked(m, keysKey)
+	keys := make([]K, 0, len(m.items))
+	for key := range m.items {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Range Обойти пары ключ-значение, пока fn возвращает true. Подписывает на любое изменение.
+// Обходится копия, снятая под блокировкой, поэтому внутри fn можно читать и менять значения
+func (m *ReactiveMap[K, V]) Range(fn func(key K, value V) bool) {
+	m.runtime.mu.Lock()
+	m.runtime.trackLocked(m, iterateKey)
+	keys := make([]K, 0, len(m.items))
+	values := make([]V, 0, len(m.items))
+	for key, value := range m.items {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	m.runtime.unlock()
+
+	for i, key := range keys {
+		if !fn(key, values[i]) {
+			return
+		}
+	}
+}
+
+// ReactiveSlice Реактивный срез с отслеживанием чтений по индексам.
+// Изменение элемента будит читателей индекса и обходов Range,
+// добавление и удаление — ещё и читателей Len и сдвинутых индексов
+type ReactiveSlice[T any] struct {
+	runtime *Runtime
+	items   []T
+}
+
+// NewReactiveSlice Конструктор в среде по умолчанию
+func NewReactiveSlice[T any](items ...T) *ReactiveSlice[T] {
+	return NewReactiveSliceIn(defaultRuntime, items...)
+}
+
+// NewReactiveSliceIn Конструктор в заданной среде
+func NewReactiveSliceIn[T any](rt *Runtime, items ...T) *ReactiveSlice[T] {
+	copied := make([]T, len(items))
+	copy(copied, items)
+
+	return &ReactiveSlice[T]{
+		runtime: rt,
+		items:   copied,
+	}
+}
+
+// Get Получить элемент по индексу. Выход за границы вызывает панику, как у обычного среза
+func (s *ReactiveSlice[T]) Get(index int) T {
+	s.runtime.mu.Lock()
+	defer s.runtime.unlock()
+
+	s.runtime.trackLocked(s, strconv.Itoa(index))
+	return s.items[index]
+}
+
+// Set Заменить элемент по индексу
+func (s *ReactiveSlice[T]) Set(index int, value T) {
+	s.runtime.mu.Lock()
+	defer s.runtime.unlock()
+
+	if reflect.DeepEqual(s.items[index], value) {
+		return
+	}
+
+	s.items[index] = value
+	s.runtime.triggerLocked(s, TriggerSet, strconv.Itoa(index), iterateKey)
+}
+
+// Append Добавить элементы в конец
+func (s *ReactiveSlice[T]) Append(values ...T) {
+	s.runtime.mu.Lock()
+	defer s.runtime.unlock()
+
+	if len(values) == 0 {
+		return
+	}
+
+	start := len(s.items)
+	s.items = append(s.items, values...)
+	s.runtime.triggerLocked(s, TriggerAdd, s.changedKeys(start, len(s.items))...)
+}
+
+// Delete Удалить элемент по индексу со сдвигом хвоста
+func (s *ReactiveSlice[T]) Delete(index int) {
+	s.runtime.mu.Lock()
+	defer s.runtime.unlock()
+
+	oldLen := len(s.items)
+	s.items = slices.Delete(s.items, index, index+1)
+	s.runtime.triggerLocked(s, TriggerDelete, s.changedKeys(index, oldLen)...)
+}
+
+// changedKeys Ключи индексов [from, to) плюс длина и обход
+func (s *ReactiveSlice[T]) changedKeys(from, to int) []string {
+	keys := make([]string, 0, to-from+2)
+	for i := from; i < to; i++ {
+		keys = append(keys, strconv.Itoa(i))
+	}
+	return append(keys, lengthKey, iterateKey)
+}
+
+// Len Длина среза
+func (s *ReactiveSlice[T]) Len() int {
+	s.runtime.mu.Lock()
+	defer s.runtime.unlock()
+
+	s.runtime.trackLocked(s, lengthKey)
+	return len(s.items)
+}
+
+// Range Обойти элементы, пока fn возвращает true. Подписывает на любое изменение.
+// Обходится копия (см. Values), поэтому внутри fn можно читать и менять значения
+func (s *ReactiveSlice[T]) Range(fn func(index int, value T) bool) {
+	for i, value := range s.Values() {
+		if !fn(i, value) {
+			return
+		}
+	}
+}
+
+// Values Копия элементов. Подписывает на любое изменение
+func (s *ReactiveSlice[T]) Values() []T {
+	s.runtime.mu.Lock()
+	defer s.runtime.unlock()
+
+	s.runtime.trackLocked(s, iterateKey)
+	values := make([]T, len(s.items))
+	copy(values, s.items)
+	return values
+}
@@ -0,0 +1,183 @@
+package reactivity
+
+import (
+	"testing"
+)
+
+// TestReactiveMapPerKey проверяем, что чтение ключа подписывает только на этот ключ
+func TestReactiveMapPerKey(t *testing.T) {
+	rows := NewReactiveMap[string, int]()
+	rows.Set("alloc", 1)
+	rows.Set("sys", 2)
+
+	allocRuns, lenRuns, rangeRuns := 0, 0, 0
+	WatchEffect(func() {
+		rows.Get("alloc")
+		allocRuns++
+	})
+	WatchEffect(func() {
+		rows.Len()
+		lenRuns++
+	})
+	WatchEffect(func() {
+		rows.Range(func(key string, value int) bool {
+			return true
+		})
+		rangeRuns++
+	})
+
+	rows.Set("sys", 20)
+	if allocRuns != 1 || lenRuns != 1 || rangeRuns != 2 {
+		t.Errorf("set чужого ключа: ожидали 1/1/2, получили %d/%d/%d", allocRuns, lenRuns, rangeRuns)
+	}
+
+	rows.Set("alloc", 10)
+	if allocRuns != 2 || lenRuns != 1 || rangeRuns != 3 {
+		t.Errorf("set своего ключа: ожидали 2/1/3, получили %d/%d/%d", allocRuns, lenRuns, rangeRuns)
+	}
+
+	rows.Set("gc", 3)
+	if allocRuns != 2 || lenRuns != 2 || rangeRuns != 4 {
+		t.Errorf("add: ожидали 2/2/4, получили %d/%d/%d", allocRuns, lenRuns, rangeRuns)
+	}
+
+	rows.Delete("alloc")
+	if allocRuns != 3 || lenRuns != 3 || rangeRuns != 5 {
+		t.Errorf("delete: ожидали 3/3/5, получили %d/%d/%d", allocRuns, lenRuns, rangeRuns)
+	}
+
+	rows.Delete("missing")
+	rows.Set("gc", 3)
+	if allocRuns != 3 || lenRuns != 3 || rangeRuns != 5 {
+		t.Errorf("Пустые изменения не должны будить эффекты, получили %d/%d/%d", allocRuns, lenRuns, rangeRuns)
+	}
+}
+
+// TestReactiveMapHasMissingKey проверяем подписку на ещё не существующий ключ
+func TestReactiveMapHasMissingKey(t *testing.T) {
+	rows := NewReactiveMap[int, string]()
+	var seen []bool
+
+	WatchEffect(func() {
+		seen = append(seen, rows.Has(42))
+	})
+
+	rows.Set(1, "один")
+	rows.Set(42, "ответ")
+
+	if len(seen) != 2 || seen[0] || !seen[1] {
+		t.Errorf("Ожидали [false true], получили %v", seen)
+	}
+}
+
+// TestReactiveMapTriggerTypes проверяем различение add/delete/set
+func TestReactiveMapTriggerTypes(t *testing.T) {
+	rows := NewReactiveMap[string, int]()
+	var types []TriggerType
+
+	WatchEffect(func() {
+		rows.Get("alloc")
+	}, WithOnTrigger(func(event DebugEvent) {
+		types = append(types, event.Type)
+	}))
+
+	rows.Set("alloc", 1)
+	rows.Set("alloc", 2)
+	rows.Delete("alloc")
+
+	if len(types) != 3 || types[0] != TriggerAdd || types[1] != TriggerSet || types[2] != TriggerDelete {
+		t.Errorf("Ожидали [add set delete], получили %v", types)
+	}
+}
+
+// TestReactiveSlicePerIndex проверяем подписку по индексам и на длину
+func TestReactiveSlicePerIndex(t *testing.T) {
+	processes := NewReactiveSlice("init", "sshd", "go")
+
+	firstRuns, lastRuns, lenRuns := 0, 0, 0
+	WatchEffect(func() {
+		processes.Get(0)
+		firstRuns++
+	})
+	WatchEffect(func() {
+		processes.Get(2)
+		lastRuns++
+	})
+	WatchEffect(func() {
+		processes.Len()
+		lenRuns++
+	})
+
+	processes.Set(2, "gopls")
+	if firstRuns != 1 || lastRuns != 2 || lenRuns != 1 {
+		t.Errorf("set: ожидали 1/2/1, получили %d/%d/%d", firstRuns, lastRuns, lenRuns)
+	}
+
+	processes.Append("bash")
+	if firstRuns != 1 || lastRuns != 2 || lenRuns != 2 {
+		t.Errorf("append: ожидали 1/2/2, получили %d/%d/%d", firstRuns, lastRuns, lenRuns)
+	}
+
+	// Удаление сдвигает хвост: индекс 2 меняется, индекс 0 — нет
+	processes.Delete(1)
+	if firstRuns != 1 || lastRuns != 3 || lenRuns != 3 {
+		t.Errorf("delete: ожидали 1/3/3, получили %d/%d/%d", firstRuns, lastRuns, lenRuns)
+	}
+
+	values := processes.Values()
+	if len(values) != 3 || values[0] != "init" || values[1] != "gopls" || values[2] != "bash" {
+		t.Errorf("Ожидали [init gopls bash], получили %v", values)
+	}
+}
+
+// TestReactiveSliceRange проверяем обход с подпиской на любые изменения
+func TestReactiveSliceRange(t *testing.T) {
+	numbers := NewReactiveSlice(1, 2, 3)
+	var sums []int
+
+	WatchEffect(func() {
+		sum := 0
+		numbers.Range(func(index int, value int) bool {
+			sum += value
+			return true
+		})
+		sums = append(sums, sum)
+	})
+
+	numbers.Set(0, 10)
+	numbers.Append(4)
+
+	if len(sums) != 3 || sums[1] != 15 || sums[2] != 19 {
+		t.Errorf("Ожидали [6 15 19], получили %v", sums)
+	}
+}
+
+// TestRangeReadsInside проверяем, что внутри Range можно читать коллекцию и другие значения
+func TestRangeReadsInside(t *testing.T) {
+	rt := NewRuntime()
+	rows := NewReactiveMapIn[string, int](rt)
+	rows.Set("alloc", 1)
+	numbers := NewReactiveSliceIn(rt, 1, 2)
+	scale := NewRefIn(rt, 1)
+
+	var totals []int
+	rt.WatchEffect(func() {
+		total := 0
+		rows.Range(func(key string, _ int) bool {
+			value, _ := rows.Get(key)
+			total += value * scale.Get()
+			return true
+		})
+		numbers.Range(func(index int, _ int) bool {
+			total += numbers.Get(index)
+			numbers.Len()
+			return true
+		})
+		totals = append(totals, total)
+	})
+
+	scale.Set(10)
+	if len(totals) != 2 || totals[1] != 13 {
+		t.Errorf("Чтения внутри Range должны подписывать эффект: ожидали [4 13], получили %v", totals)
+	}
+}
@@ -0,0 +1,103 @@
+package reactivity
+
+import (
+	"fmt"
+)
+
+// Computed Вычисляемое (производное) реактивное значение.
+// Значение считается лениво при первом Get и кэшируется до тех пор,
+// пока одна из отслеживаемых зависимостей не сработает.
+type Computed[T any] struct {
+	effect *ReactiveEffect
+	dep    *Dep
+	value  T
+	dirty  bool
+	// computing Сколько пересчётов идёт сейчас: пока значение считается, остальные
+	// читатели не ждут, а пересчитывают его сами
+	computing int
+}
+
+// NewComputed Конструктор в среде по умолчанию. getter не вызывается до первого Get
+func NewComputed[T any](getter func() T) *Computed[T] {
+	return NewComputedIn(defaultRuntime, getter)
+}
+
+// NewComputedIn Конструктор в заданной среде
+func NewComputedIn[T any](rt *Runtime, getter func() T) *Computed[T] {
+	c := &Computed[T]{
+		dep:   rt.NewDep(),
+		dirty: true,
+	}
+	c.dep.targetType = fmt.Sprintf("%T", c)
+	c.dep.key = refValueKey
+
+	c.effect = rt.NewReactiveEffect(func() {
+		value := getter()
+
+		rt.mu.Lock()
+		c.value = value
+		rt.mu.Unlock()
+	})
+
+	c.effect.output = c.dep
+
+	// Вместо перезапуска только помечаем значение грязным и оповещаем своих подписчиков.
+	// Вызывается под блокировкой
+	c.effect.onDirty = func() {
+		if !c.dirty {
+			c.dirty = true
+			c.dep.notify(TriggerSet)
+		}
+	}
+
+	return c
+}
+
+// Get Получить значение. Внутри эффекта подписывает его на это вычисляемое значение
+func (c *Computed[T]) Get() T {
+	rt := c.dep.runtime
+	c.refresh()
+
+	rt.mu.Lock()
+	defer rt.unlock()
+
+	// Подписываемся после пересчёта, чтобы читатель встал в графе выше вычисляемого значения
+	c.dep.track()
+
+	return c.value
+}
+
+// refresh Пересчитать значение, если оно устарело. getter вызывается вне блокировки
+func (c *Computed[T]) refresh() {
+	rt := c.dep.runtime
+	rt.mu.Lock()
+	if !c.dirty && c.computing == 0 {
+		rt.mu.Unlock()
+		return
+	}
+	c.dirty = false
+	c.computing++
+	rt.mu.Unlock()
+
+	c.effect.Run()
+
+	rt.mu.Lock()
+	c.computing--
+	c.dep.height = c.effect.height
+	rt.mu.Unlock()
+}
+
+// Dirty Нужно ли пересчитать значение при следующем Get
+func (c *Computed[T]) Dirty() bool {
+	c.dep.runtime.mu.Lock()
+	defer c.dep.runtime.mu.Unlock()
+
+	return c.dirty
+}
+
+// Stop Отписаться от зависимостей. Устаревшее значение пересчитывается в последний раз,
+// поэтому после остановки Get возвращает значение на момент Stop, даже если его ни разу не читали
+func (c *Computed[T]) Stop() {
+	c.refresh()
+	c.effect.Stop()
+}
@@ -0,0 +1,150 @@
+package reactivity
+
+import (
+	"testing"
+)
+
+type testCounter struct {
+	Value int
+}
+
+// TestComputedLazy проверяем, что геттер не вызывается до первого Get и результат кэшируется
+func TestComputedLazy(t *testing.T) {
+	counter := &testCounter{Value: 2}
+	calls := 0
+
+	double := NewComputed(func() int {
+		calls++
+		Track(counter, "Value")
+		return counter.Value * 2
+	})
+
+	if calls != 0 {
+		t.Errorf("Геттер не должен вызываться до Get, вызван %d раз", calls)
+	}
+
+	if got := double.Get(); got != 4 {
+		t.Errorf("Ожидали 4, получили %d", got)
+	}
+	double.Get()
+
+	if calls != 1 {
+		t.Errorf("Ожидали 1 вызов геттера, получили %d", calls)
+	}
+}
+
+// TestComputedDirty проверяем, что значение пересчитывается только после срабатывания зависимости
+func TestComputedDirty(t *testing.T) {
+	counter := &testCounter{Value: 1}
+	calls := 0
+
+	double := NewComputed(func() int {
+		calls++
+		Track(counter, "Value")
+		return counter.Value * 2
+	})
+	double.Get()
+
+	counter.Value = 5
+	Trigger(counter, "Value")
+
+	if !double.Dirty() {
+		t.Error("Значение должно быть помечено грязным после Trigger")
+	}
+
+	if calls != 1 {
+		t.Errorf("Trigger не должен пересчитывать значение сам, вызовов %d", calls)
+	}
+
+	if got := double.Get(); got != 10 {
+		t.Errorf("Ожидали 10, получили %d", got)
+	}
+}
+
+// TestComputedTrackedByEffect проверяем, что эффект перезапускается при изменении вычисляемого значения
+func TestComputedTrackedByEffect(t *testing.T) {
+	counter := &testCounter{Value: 1}
+
+	double := NewComputed(func() int {
+		Track(counter, "Value")
+		return counter.Value * 2
+	})
+
+	var seen []int
+	WatchEffect(func() {
+		seen = append(seen, double.Get())
+	})
+
+	counter.Value = 3
+	Trigger(counter, "Value")
+
+	if len(seen) != 2 || seen[0] != 2 || seen[1] != 6 {
+		t.Errorf("Ожидали [2 6], получили %v", seen)
+	}
+}
+
+// TestComputedChain проверяем цепочку вычисляемых значений
+func TestComputedChain(t *testing.T) {
+	counter := &testCounter{Value: 1}
+
+	double := NewComputed(func() int {
+		Track(counter, "Value")
+		return counter.Value * 2
+	})
+	quadruple := NewComputed(func() int {
+		return double.Get() * 2
+	})
+
+	if got := quadruple.Get(); got != 4 {
+		t.Errorf("Ожидали 4, получили %d", got)
+	}
+
+	counter.Value = 2
+	Trigger(counter, "Value")
+
+	if got := quadruple.Get(); got != 8 {
+		t.Errorf("Ожидали 8, получили %d", got)
+	}
+}
+
+// TestComputedStop проверяем, что после Stop значение больше не помечается грязным
+func TestComputedStop(t *testing.T) {
+	counter := &testCounter{Value: 1}
+
+	double := NewComputed(func() int {
+		Track(counter, "Value")
+		return counter.Value * 2
+	})
+	double.Get()
+	double.Stop()
+
+	counter.Value = 7
+	Trigger(counter, "Value")
+
+	if double.Dirty() {
+		t.Error("Остановленное значение не должно становиться грязным")
+	}
+
+	if got := double.Get(); got != 2 {
+		t.Errorf("Ожидали последнее значение 2, получили %d", got)
+	}
+}
+
+// TestComputedStopBeforeGet проверяем, что Stop до первого Get фиксирует значение на момент остановки
+func TestComputedStopBeforeGet(t *testing.T) {
+	rt := NewRuntime()
+	counter := NewRefIn(rt, 3)
+
+	double := NewComputedIn(rt, func() int {
+		return counter.Get() * 2
+	})
+	double.Stop()
+	counter.Set(5)
+
+	if double.Dirty() {
+		t.Error("Остановленное значение не должно становиться грязным")
+	}
+	if got := double.Get(); got != 6 {
+		t.Errorf("Ожидали значение на момент Stop 6, получили %d", got)
+	}
+}
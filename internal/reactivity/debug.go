@@ -0,0 +1,212 @@
+package reactivity
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DebugEvent Событие для отладочных обработчиков OnTrack/OnTrigger
+type DebugEvent struct {
+	Effect *ReactiveEffect
+	Target string // тип цели, например *main.MemoryMonitorReport
+	Key    string
+	Type   TriggerType // пустой для OnTrack
+}
+
+// addHookLocked Отложить отладочный обработчик эффекта до снятия блокировки (см. Runtime.unlock).
+// Паника в обработчике сообщается как ошибка эффекта
+func (rt *Runtime) addHookLocked(effect *ReactiveEffect, hook func()) {
+	rt.hooks = append(rt.hooks, func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				rt.reportPanic(effect, recovered)
+			}
+		}()
+		hook()
+	})
+}
+
+// Graph Снимок графа зависимостей: цель -> ключ -> Dep -> эффекты.
+// Вычисляемые значения — тоже цели: их зависимость пересчитывает свой эффект
+type Graph struct {
+	Targets []GraphTarget `json:"targets"`
+}
+
+// GraphTarget Цель в графе
+type GraphTarget struct {
+	Type    string     `json:"type"`
+	Address string     `json:"address"`
+	Keys    []GraphDep `json:"keys"`
+	// Effect ID эффекта, который вычисляет цель (у Computed), 0 у обычных целей
+	Effect int `json:"effect,omitempty"`
+}
+
+// GraphDep Зависимость ключа цели
+type GraphDep struct {
+	Key     string        `json:"key"`
+	DepID   int           `json:"depId"`
+	Effects []GraphEffect `json:"effects"`
+}
+
+// GraphEffect Подписанный эффект
+type GraphEffect struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Active bool   `json:"active"`
+}
+
+// ExportGraph Снять граф зависимостей среды. Порядок детерминирован: цели по типу и адресу,
+// ключи по имени, эффекты по ID. Зависимости Computed не лежат в карте целей, поэтому
+// находятся обходом от их эффектов, подписанных на цели карты
+func (rt *Runtime) ExportGraph() Graph {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	graph := Graph{Targets: make([]GraphTarget, 0, len(rt.targetMap))}
+	var pending []*ReactiveEffect
+
+	for objectID, depsMap := range rt.targetMap {
+		target := GraphTarget{
+			Address: fmt.Sprintf("%p", objectID.Value()),
+			Keys:    make([]GraphDep, 0, len(depsMap)),
+		}
+
+		for key, dep := range depsMap {
+			target.Type = dep.targetType
+			target.Keys = append(target.Keys, graphDep(key, dep))
+			pending = append(pending, dep.Subscribers...)
+		}
+		sort.Slice(target.Keys, func(i, j int) bool {
+			return target.Keys[i].Key < target.Keys[j].Key
+		})
+
+		graph.Targets = append(graph.Targets, target)
+	}
+
+	visited := make(map[*Dep]bool)
+	for len(pending) > 0 {
+		effect := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		dep := effect.output
+		if dep == nil || visited[dep] {
+			continue
+		}
+		visited[dep] = true
+
+		graph.Targets = append(graph.Targets, GraphTarget{
+			Type:    dep.targetType,
+			Address: fmt.Sprintf("%p", dep),
+			Keys:    []GraphDep{graphDep(dep.key, dep)},
+			Effect:  effect.ID,
+		})
+		pending = append(pending, dep.Subscribers...)
+	}
+
+	sort.Slice(graph.Targets, func(i, j int) bool {
+		if graph.Targets[i].Type != graph.Targets[j].Type {
+			return graph.Targets[i].Type < graph.Targets[j].Type
+		}
+		return graph.Targets[i].Address < graph.Targets[j].Address
+	})
+
+	return graph
+}
+
+// graphDep Снимок зависимости с подписчиками по ID
+func graphDep(key string, dep *Dep) GraphDep {
+	result := GraphDep{
+		Key:     key,
+		DepID:   dep.ID,
+		Effects: make([]GraphEffect, 0, len(dep.Subscribers)),
+	}
+	for _, effect := range dep.Subscribers {
+		result.Effects = append(result.Effects, GraphEffect{
+			ID:     effect.ID,
+			Name:   effect.Name,
+			Active: effect.Active,
+		})
+	}
+	sort.Slice(result.Effects, func(i, j int) bool {
+		return result.Effects[i].ID < result.Effects[j].ID
+	})
+	return result
+}
+
+// ExportJSON Граф зависимостей в JSON
+func (rt *Runtime) ExportJSON() ([]byte, error) {
+	return json.MarshalIndent(rt.ExportGraph(), "", "  ")
+}
+
+// ExportDOT Граф зависимостей в формате Graphviz DOT
+func (rt *Runtime) ExportDOT() string {
+	return rt.ExportGraph().DOT()
+}
+
+// DOT Граф в формате Graphviz DOT
+func (g Graph) DOT() string {
+	var b strings.Builder
+
+	b.WriteString("digraph reactivity {\n")
+	b.WriteString("  rankdir=LR;\n")
+
+	effects := make(map[int]GraphEffect)
+	for i, target := range g.Targets {
+		targetNode := fmt.Sprintf("target_%d", i)
+		fmt.Fprintf(&b, "  %s [shape=box, label=%q];\n", targetNode, target.Type+"\n"+target.Address)
+		if target.Effect != 0 {
+			fmt.Fprintf(&b, "  effect_%d -> %s;\n", target.Effect, targetNode)
+		}
+
+		for _, dep := range target.Keys {
+			depNode := fmt.Sprintf("dep_%d", dep.DepID)
+			fmt.Fprintf(&b, "  %s [shape=ellipse, label=%q];\n", depNode, fmt.Sprintf("%s (dep #%d)", dep.Key, dep.DepID))
+			fmt.Fprintf(&b, "  %s -> %s;\n", targetNode, depNode)
+
+			for _, effect := range dep.Effects {
+				effects[effect.ID] = effect
+				fmt.Fprintf(&b, "  %s -> effect_%d;\n", depNode, effect.ID)
+			}
+		}
+	}
+
+	ids := make([]int, 0, len(effects))
+	for id := range effects {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	for _, id := range ids {
+		effect := effects[id]
+		label := fmt.Sprintf("effect #%d", effect.ID)
+		if effect.Name != "" {
+			label += "\n" + effect.Name
+		}
+
+		style := ""
+		if !effect.Active {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  effect_%d [shape=component, label=%q%s];\n", effect.ID, label, style)
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// ExportGraph Снимок графа среды по умолчанию
+func ExportGraph() Graph {
+	return defaultRuntime.ExportGraph()
+}
+
+// ExportJSON Граф среды по умолчанию в JSON
+func ExportJSON() ([]byte, error) {
+	return defaultRuntime.ExportJSON()
+}
+
+// ExportDOT Граф среды по умолчанию в формате Graphviz DOT
+func ExportDOT() string {
+	return defaultRuntime.ExportDOT()
+}
@@ -0,0 +1,135 @@
+package reactivity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestDebugHooks проверяем вызовы OnTrack и OnTrigger
+func TestDebugHooks(t *testing.T) {
+	rt := NewRuntime()
+	report := &testReport{}
+	var tracked, triggered []DebugEvent
+
+	effect := rt.WatchEffect(func() {
+		rt.Track(report, "AllocMB")
+	},
+		WithName("dashboard"),
+		WithOnTrack(func(event DebugEvent) {
+			tracked = append(tracked, event)
+		}),
+		WithOnTrigger(func(event DebugEvent) {
+			triggered = append(triggered, event)
+		}),
+	)
+
+	rt.Trigger(report, "AllocMB")
+
+	if len(tracked) != 2 || tracked[0].Key != "AllocMB" || tracked[0].Target != "*reactivity.testReport" {
+		t.Errorf("Ожидали 2 подписки на AllocMB, получили %+v", tracked)
+	}
+	if len(triggered) != 1 || triggered[0].Effect != effect {
+		t.Errorf("Ожидали 1 срабатывание, получили %+v", triggered)
+	}
+	if effect.String() != "#1 (dashboard)" {
+		t.Errorf("Неверное описание эффекта: %s", effect.String())
+	}
+}
+
+// TestExportGraph проверяем снимок графа
+func TestExportGraph(t *testing.T) {
+	rt := NewRuntime()
+	report := &testReport{}
+
+	rt.WatchEffect(func() {
+		rt.Track(report, "SysMB")
+		rt.Track(report, "AllocMB")
+	}, WithName("dashboard"))
+	rt.WatchEffect(func() {
+		rt.Track(report, "AllocMB")
+	})
+
+	graph := rt.ExportGraph()
+	if len(graph.Targets) != 1 {
+		t.Fatalf("Ожидали 1 цель, получили %d", len(graph.Targets))
+	}
+
+	target := graph.Targets[0]
+	if target.Type != "*reactivity.testReport" || len(target.Keys) != 2 {
+		t.Fatalf("Неверная цель: %+v", target)
+	}
+	if target.Keys[0].Key != "AllocMB" || len(target.Keys[0].Effects) != 2 {
+		t.Errorf("Ожидали 2 эффекта на AllocMB, получили %+v", target.Keys[0])
+	}
+	if target.Keys[1].Effects[0].Name != "dashboard" {
+		t.Errorf("Ожидали эффект dashboard на SysMB, получили %+v", target.Keys[1])
+	}
+
+	data, err := rt.ExportJSON()
+	if err != nil {
+		t.Fatalf("Ошибка JSON: %v", err)
+	}
+	var decoded Graph
+	if err := json.Unmarshal(data, &decoded); err != nil || len(decoded.Targets) != 1 {
+		t.Errorf("JSON должен читаться обратно: %v", err)
+	}
+
+	dot := rt.ExportDOT()
+	for _, want := range []string{"digraph reactivity", "AllocMB (dep #", "effect #1\\ndashboard", "-> effect_2;"} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT должен содержать %q:\n%s", want, dot)
+		}
+	}
+}
+
+// TestExportGraphComputed проверяем цепочку эффект -> Computed -> Computed -> цель в снимке графа
+func TestExportGraphComputed(t *testing.T) {
+	rt := NewRuntime()
+	report := &testReport{}
+
+	alloc := NewComputedIn(rt, func() string {
+		rt.Track(report, "AllocMB")
+		return report.AllocMB
+	})
+	label := NewComputedIn(rt, func() int {
+		return len(alloc.Get())
+	})
+	rt.WatchEffect(func() {
+		label.Get()
+	}, WithName("dashboard"))
+
+	graph := rt.ExportGraph()
+	if len(graph.Targets) != 3 {
+		t.Fatalf("Ожидали 3 цели (отчёт и два Computed), получили %+v", graph.Targets)
+	}
+
+	// Цели по типу: Computed[int] (label), Computed[string] (alloc), отчёт
+	labelTarget, allocTarget, reportTarget := graph.Targets[0], graph.Targets[1], graph.Targets[2]
+	if labelTarget.Type != "*reactivity.Computed[int]" || allocTarget.Type != "*reactivity.Computed[string]" {
+		t.Fatalf("Неверные цели Computed: %+v, %+v", allocTarget, labelTarget)
+	}
+
+	// Отчёт читает эффект alloc, alloc — эффект label, label — dashboard
+	if got := reportTarget.Keys[0].Effects; len(got) != 1 || got[0].ID != allocTarget.Effect {
+		t.Errorf("На AllocMB должен быть подписан эффект alloc #%d, получили %+v", allocTarget.Effect, got)
+	}
+	if got := allocTarget.Keys[0].Effects; len(got) != 1 || got[0].ID != labelTarget.Effect {
+		t.Errorf("На alloc должен быть подписан эффект label #%d, получили %+v", labelTarget.Effect, got)
+	}
+	if got := labelTarget.Keys[0].Effects; len(got) != 1 || got[0].Name != "dashboard" {
+		t.Errorf("На label должен быть подписан dashboard, получили %+v", got)
+	}
+
+	dot := rt.ExportDOT()
+	for _, want := range []string{
+		fmt.Sprintf("effect_%d -> target_0;", labelTarget.Effect),
+		fmt.Sprintf("effect_%d -> target_1;", allocTarget.Effect),
+		"effect #3\\ndashboard",
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT должен содержать %q:\n%s", want, dot)
+		}
+	}
+}
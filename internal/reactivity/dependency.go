@@ -1,18 +1,22 @@
 package reactivity
 
+import (
+	"weak"
+)
+
 type Dep struct {
 	ID          int
 	Subscribers []*ReactiveEffect
 	subsMap     map[*ReactiveEffect]bool
-}
+	runtime     *Runtime
 
-func NewDep() *Dep {
-	depIdSeq++
-	return &Dep{
-		ID:          depIdSeq,
-		Subscribers: make([]*ReactiveEffect, 0),
-		subsMap:     make(map[*ReactiveEffect]bool),
-	}
+	// Владелец в карте целей; пустой target у зависимостей вне карты (например, Computed)
+	target weak.Pointer[byte]
+	key    string
+	// targetType Тип цели для отчётов об ошибках, например *main.MemoryMonitorReport
+	targetType string
+	// height Высота самого высокого эффекта, который писал в зависимость
+	height int
 }
 
 func (d *Dep) addSub(effect *ReactiveEffect) {
@@ -35,20 +39,82 @@ func (d *Dep) removeSub(effect *ReactiveEffect) {
 	}
 }
 
-func (d *Dep) notify() {
+// detach Отвязать всех подписчиков от зависимости
+func (d *Dep) detach() {
 	for _, effect := range d.Subscribers {
-		if effect.Active {
-			effect.Run()
+		for i, dep := range effect.Deps {
+			if dep == d {
+				effect.Deps = append(effect.Deps[:i], effect.Deps[i+1:]...)
+				break
+			}
+		}
+	}
+	d.Subscribers = d.Subscribers[:0]
+	clear(d.subsMap)
+}
+
+// notify Оповестить подписчиков. Вызывается под блокировкой
+func (d *Dep) notify(triggerType TriggerType) {
+	// Копируем подписчиков: onDirty вычисляемых значений меняет подписки по цепочке
+	subscribers := make([]*ReactiveEffect, len(d.Subscribers))
+	copy(subscribers, d.Subscribers)
+
+	active := d.runtime.currentEffectLocked()
+	event := TriggerEvent{Target: d.targetType, Key: d.key, Type: triggerType}
+	if active != nil {
+		event.EffectID = active.ID
+		// Зависимость, в которую пишет эффект, стоит в графе на его высоте
+		d.height = max(d.height, active.height)
+	}
+
+	for _, effect := range subscribers {
+		if !effect.Active {
+			continue
+		}
+		// Эффект, который сам изменил прочитанное значение, не перезапускается
+		if effect == active {
+			continue
 		}
+		d.notifyEffect(effect, event, active)
+	}
+}
+
+// notifyEffect Оповестить одного подписчика. Отладочный обработчик откладывается
+// до снятия блокировки; его паника не мешает оповестить остальных
+func (d *Dep) notifyEffect(effect *ReactiveEffect, event TriggerEvent, active *ReactiveEffect) {
+	rt := d.runtime
+	if effect.OnTrigger != nil {
+		debugEvent := DebugEvent{Effect: effect, Target: d.targetType, Key: d.key, Type: event.Type}
+		rt.addHookLocked(effect, func() {
+			effect.OnTrigger(debugEvent)
+		})
+	}
+	if effect.onDirty != nil {
+		effect.onDirty()
+		return
 	}
+	rt.queueEffect(effect, event, active)
 }
 
+// track Подписать активный эффект. Вызывается под блокировкой
 func (d *Dep) track() {
-	if activeEffect != nil {
-		d.addSub(activeEffect)
+	if d.runtime.shouldTrack {
+		d.trackEffect(d.runtime.currentEffectLocked())
 	}
 }
 
-func (d *Dep) trigger() {
-	d.notify()
+// trackEffect Подписать эффект, если он есть. Вызывается под блокировкой
+func (d *Dep) trackEffect(effect *ReactiveEffect) {
+	if effect == nil {
+		return
+	}
+
+	d.addSub(effect)
+	effect.height = max(effect.height, d.height+1)
+	if effect.OnTrack != nil {
+		debugEvent := DebugEvent{Effect: effect, Target: d.targetType, Key: d.key}
+		d.runtime.addHookLocked(effect, func() {
+			effect.OnTrack(debugEvent)
+		})
+	}
 }
@@ -1,39 +1,227 @@
 package reactivity
 
+import (
+	"fmt"
+)
+
 type ReactiveEffect struct {
 	ID     int
 	Fn     EffectFunc
 	Deps   []*Dep
 	Active bool
 	OnStop []func()
+
+	// Scheduler решает, когда перезапустить эффект после срабатывания зависимости (nil — SyncScheduler)
+	Scheduler Scheduler
+
+	// Name Имя для отладки и отчётов об ошибках
+	Name string
+	// OnTrack вызывается при каждой подписке эффекта на ключ цели
+	OnTrack func(event DebugEvent)
+	// OnTrigger вызывается, когда срабатывание зависимости задевает эффект
+	OnTrigger func(event DebugEvent)
+
+	// StopOnPanic остановить эффект после паники внутри Fn
+	StopOnPanic bool
+
+	// onDirty вызывается вместо Run при срабатывании зависимости (используется Computed)
+	onDirty func()
+	// output Зависимость значения, которое пересчитывает эффект (у Computed): по ней ExportGraph
+	// продолжает граф от эффекта к читателям вычисляемого значения
+	output  *Dep
+	runtime *Runtime
+	// scope Область, в которой эффект создан; активна во время каждого его запуска
+	scope *EffectScope
+	// running Глубина вложенных запусков эффекта
+	running int
+	// cause Цепочка срабатываний, из-за которой эффект поставлен в очередь
+	cause []TriggerEvent
+	// height Высота в графе: на 1 больше самой высокой прочитанной зависимости
+	height   int
+	queueSeq int
+	// cleanups Обработчики OnCleanup текущего запуска
+	cleanups []func()
+}
+
+// EffectOption Настройка эффекта при создании
+type EffectOption func(effect *ReactiveEffect)
+
+// WithScheduler Задать планировщик перезапусков эффекта
+func WithScheduler(scheduler Scheduler) EffectOption {
+	return func(effect *ReactiveEffect) {
+		effect.Scheduler = scheduler
+	}
 }
 
-func NewReactiveEffect(fn EffectFunc) *ReactiveEffect {
-	effectIdSeq++
-	return &ReactiveEffect{
-		ID:     effectIdSeq,
-		Fn:     fn,
-		Deps:   make([]*Dep, 0),
-		Active: true,
+// WithName Задать имя эффекта для отладки
+func WithName(name string) EffectOption {
+	return func(effect *ReactiveEffect) {
+		effect.Name = name
 	}
 }
 
+// WithOnTrack Задать отладочный обработчик подписок
+func WithOnTrack(hook func(event DebugEvent)) EffectOption {
+	return func(effect *ReactiveEffect) {
+		effect.OnTrack = hook
+	}
+}
+
+// WithOnTrigger Задать отладочный обработчик срабатываний
+func WithOnTrigger(hook func(event DebugEvent)) EffectOption {
+	return func(effect *ReactiveEffect) {
+		effect.OnTrigger = hook
+	}
+}
+
+// WithStopOnPanic Останавливать эффект после паники внутри Fn
+func WithStopOnPanic() EffectOption {
+	return func(effect *ReactiveEffect) {
+		effect.StopOnPanic = true
+	}
+}
+
+// String Описание эффекта для логов: #3 или #3 (dashboard)
+func (e *ReactiveEffect) String() string {
+	if e.Name == "" {
+		return fmt.Sprintf("#%d", e.ID)
+	}
+	return fmt.Sprintf("#%d (%s)", e.ID, e.Name)
+}
+
+// Runtime Среда, которой принадлежит эффект
+func (e *ReactiveEffect) Runtime() *Runtime {
+	return e.runtime
+}
+
+// runFrame Запуск эффекта в стеке среды и состояние, которое нужно вернуть после него
+type runFrame struct {
+	effect          *ReactiveEffect
+	prevScope       *EffectScope
+	prevShouldTrack bool
+}
+
+// Run Запустить эффект. Тело выполняет владелец цикла (см. owner.go): горутина, которая
+// им не является, ждёт, пока цикл освободится. Если очередь среды никто не разбирает,
+// после тела задетые эффекты разбираются здесь же
 func (e *ReactiveEffect) Run() {
+	runOwned(e.run)
+}
+
+func (e *ReactiveEffect) run() {
+	rt := e.runtime
+	rt.mu.Lock()
+
 	if !e.Active {
+		rt.unlock()
+		return
+	}
+
+	if rt.tooManyRuns(e) {
+		rt.unlock()
+		rt.reportLoop(e)
 		return
 	}
 
-	prevEffect := activeEffect
-	effectStack = append(effectStack, e)
+	e.running++
+	drain := !rt.flushing
+	if drain {
+		rt.flushing = true
+	}
+	rt.flushRuns[e]++
+	rt.pushFrameLocked(e)
+	rt.unlock()
 
+	// Паника в Fn не должна ломать горутину, вызвавшую Trigger, и остальных подписчиков
 	defer func() {
-		effectStack = effectStack[:len(effectStack)-1]
-		activeEffect = prevEffect
+		recovered := recover()
+
+		rt.mu.Lock()
+		e.running--
+		rt.popFrameLocked()
+		rt.unlock()
+
+		if recovered != nil {
+			rt.reportPanic(e, recovered)
+		}
+
+		if drain {
+			rt.mu.Lock()
+			rt.drainLocked()
+			rt.mu.Unlock()
+		}
 	}()
 
-	activeEffect = e
+	e.runCleanups()
+
+	rt.mu.Lock()
+	e.height = 0
 	e.cleanupDeps()
-	e.Fn()
+	rt.mu.Unlock()
+
+	// Через ownerCall: чтения внутри тела быстро находят кадр владельца (см. isOwner)
+	ownerCall(e.Fn)
+}
+
+// pushFrameLocked Сделать эффект активным. Эффект отслеживает свои зависимости,
+// даже если запущен внутри Untracked
+func (rt *Runtime) pushFrameLocked(e *ReactiveEffect) {
+	rt.effectStack = append(rt.effectStack, runFrame{
+		effect:          e,
+		prevScope:       rt.activeScope,
+		prevShouldTrack: rt.shouldTrack,
+	})
+	rt.activeEffect = e
+	rt.activeScope = e.scope
+	rt.shouldTrack = true
+}
+
+// popFrameLocked Снять верхний запуск со стека и вернуть состояние до него.
+// Стек меняет только владелец цикла, поэтому запуски заканчиваются в обратном порядке
+func (rt *Runtime) popFrameLocked() {
+	last := len(rt.effectStack) - 1
+	frame := rt.effectStack[last]
+	rt.effectStack = rt.effectStack[:last]
+
+	rt.activeScope = frame.prevScope
+	rt.shouldTrack = frame.prevShouldTrack
+	rt.activeEffect = nil
+	if last > 0 {
+		rt.activeEffect = rt.effectStack[last-1].effect
+	}
+}
+
+// currentEffectLocked Эффект, от имени которого работает вызывающая горутина: активный
+// эффект среды, если горутина — владелец цикла. Вызывается под блокировкой
+func (rt *Runtime) currentEffectLocked() *ReactiveEffect {
+	if rt.activeEffect == nil || !isOwner() {
+		return nil
+	}
+	return rt.activeEffect
+}
+
+// addCleanup Зарегистрировать обработчик перед следующим запуском.
+// При первой регистрации он же подключается к OnStop, чтобы сработать и при остановке
+func (e *ReactiveEffect) addCleanup(fn func()) {
+	if e.cleanups == nil {
+		e.OnStop = append(e.OnStop, e.runCleanups)
+	}
+	e.cleanups = append(e.cleanups, fn)
+}
+
+// runCleanups Вызвать обработчики OnCleanup текущего запуска вне блокировки
+func (e *ReactiveEffect) runCleanups() {
+	rt := e.runtime
+	rt.mu.Lock()
+	cleanups := e.cleanups
+	if len(cleanups) > 0 {
+		e.cleanups = make([]func(), 0)
+	}
+	rt.mu.Unlock()
+
+	for _, cleanup := range cleanups {
+		cleanup()
+	}
 }
 
 func (e *ReactiveEffect) cleanupDeps() {
@@ -43,18 +231,26 @@ func (e *ReactiveEffect) cleanupDeps() {
 	e.Deps = e.Deps[:0]
 }
 
+// Stop Остановить эффект: отписать от зависимостей и вызвать обработчики OnStop
 func (e *ReactiveEffect) Stop() {
-	if e.Active {
-		e.cleanupDeps()
-		e.Active = false
-		for _, onStop := range e.OnStop {
-			onStop()
-		}
+	rt := e.runtime
+	rt.mu.Lock()
+
+	if !e.Active {
+		rt.unlock()
+		return
 	}
-}
 
-func WatchEffect(fn EffectFunc) *ReactiveEffect {
-	effect := NewReactiveEffect(fn)
-	effect.Run()
-	return effect
+	deps := append([]*Dep(nil), e.Deps...)
+	e.cleanupDeps()
+	for _, dep := range deps {
+		rt.releaseDep(dep)
+	}
+	e.Active = false
+	onStop := append([]func(){}, e.OnStop...)
+	rt.unlock()
+
+	for _, fn := range onStop {
+		fn()
+	}
 }
@@ -0,0 +1,181 @@
+package reactivity
+
+import (
+	logger "Guess/internal"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// DefaultMaxRecursion Сколько раз эффект может запуститься за один сброс очереди
+// (и насколько глубоко вложиться сам в себя), прежде чем запуск считается бесконечным циклом
+const DefaultMaxRecursion = 100
+
+// maxCauseChain Сколько последних срабатываний хранится в цепочке причин
+const maxCauseChain = 32
+
+// ErrInfiniteLoop Эффект перезапускает сам себя через цепочку зависимостей
+var ErrInfiniteLoop = errors.New("эффект перезапускается бесконечно")
+
+// ErrEffectPanic Паника внутри эффекта или его планировщика
+var ErrEffectPanic = errors.New("паника в эффекте")
+
+// TriggerEvent Одно срабатывание зависимости: цель, ключ и эффект, который его вызвал
+type TriggerEvent struct {
+	Target   string // тип цели, например *main.MemoryMonitorReport
+	Key      string
+	Type     TriggerType
+	EffectID int // 0, если срабатывание вызвано вне эффекта
+}
+
+func (t TriggerEvent) String() string {
+	description := fmt.Sprintf("%s.%s", t.Target, t.Key)
+	if t.Type == TriggerAdd || t.Type == TriggerDelete {
+		description += fmt.Sprintf(" [%s]", t.Type)
+	}
+	if t.EffectID != 0 {
+		description += fmt.Sprintf(" (эффект #%d)", t.EffectID)
+	}
+	return description
+}
+
+// EffectError Ошибка выполнения эффекта
+type EffectError struct {
+	EffectID   int
+	EffectName string
+	// Chain Цепочка срабатываний от первоначального изменения до запуска эффекта
+	Chain []TriggerEvent
+	// Target и Key Последнее срабатывание, перезапустившее эффект (пустые при первом запуске)
+	Target string
+	Key    string
+	Err    error
+	// Panic Значение паники и стек, если эффект упал
+	Panic interface{}
+	Stack []byte
+}
+
+func (e *EffectError) Error() string {
+	effect := fmt.Sprintf("#%d", e.EffectID)
+	if e.EffectName != "" {
+		effect = fmt.Sprintf("#%d (%s)", e.EffectID, e.EffectName)
+	}
+
+	message := fmt.Sprintf("reactivity: эффект %s: %v", effect, e.Err)
+	if len(e.Chain) == 0 {
+		return message
+	}
+
+	chain := make([]string, len(e.Chain))
+	for i, event := range e.Chain {
+		chain[i] = event.String()
+	}
+	return message + "; цепочка: " + strings.Join(chain, " -> ")
+}
+
+func (e *EffectError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorHandler Обработчик ошибок эффектов. Вызывается вне блокировки графа
+type ErrorHandler func(err *EffectError)
+
+// LogErrorHandler Обработчик по умолчанию: пишет ошибку в лог
+func LogErrorHandler(err *EffectError) {
+	logger.ErrorLog("reactivity", err.Error())
+}
+
+// SetErrorHandler Задать обработчик ошибок эффектов (nil — LogErrorHandler)
+func (rt *Runtime) SetErrorHandler(handler ErrorHandler) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if handler == nil {
+		handler = LogErrorHandler
+	}
+	rt.errorHandler = handler
+}
+
+// SetMaxRecursion Задать предел запусков эффекта за один сброс очереди
+func (rt *Runtime) SetMaxRecursion(limit int) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	rt.maxRecursion = limit
+}
+
+// SetErrorHandler Задать обработчик ошибок эффектов в среде по умолчанию
+func SetErrorHandler(handler ErrorHandler) {
+	defaultRuntime.SetErrorHandler(handler)
+}
+
+// causeChain Цепочка причин для эффекта, поставленного в очередь событием event:
+// причины эффекта active, который вызвал событие, плюс само событие
+func causeChain(event TriggerEvent, active *ReactiveEffect) []TriggerEvent {
+	var chain []TriggerEvent
+	if active != nil {
+		chain = append(chain, active.cause...)
+	}
+	chain = append(chain, event)
+
+	if len(chain) > maxCauseChain {
+		chain = chain[len(chain)-maxCauseChain:]
+	}
+	return chain
+}
+
+// tooManyRuns Превысил ли эффект предел вложенности или запусков за сброс
+func (rt *Runtime) tooManyRuns(effect *ReactiveEffect) bool {
+	return effect.running >= rt.maxRecursion || rt.flushRuns[effect] >= rt.maxRecursion
+}
+
+// newEffectError Ошибка эффекта с цепочкой причин его последнего запуска. Вызывается под блокировкой
+func newEffectError(effect *ReactiveEffect, err error) *EffectError {
+	effectErr := &EffectError{
+		EffectID:   effect.ID,
+		EffectName: effect.Name,
+		Chain:      effect.cause,
+		Err:        err,
+	}
+
+	if len(effect.cause) > 0 {
+		last := effect.cause[len(effect.cause)-1]
+		effectErr.Target = last.Target
+		effectErr.Key = last.Key
+	}
+
+	return effectErr
+}
+
+// reportPanic Сообщить о панике в эффекте и при необходимости остановить его.
+// Вызывается вне блокировки
+func (rt *Runtime) reportPanic(effect *ReactiveEffect, recovered interface{}) {
+	rt.mu.Lock()
+	effectErr := newEffectError(effect, fmt.Errorf("%w: %v", ErrEffectPanic, recovered))
+	handler := rt.errorHandler
+	rt.mu.Unlock()
+
+	effectErr.Panic = recovered
+	effectErr.Stack = debug.Stack()
+
+	if effect.StopOnPanic {
+		effect.Stop()
+	}
+
+	handler(effectErr)
+}
+
+// reportLoop Сообщить о бесконечном цикле один раз за сброс. Вызывается вне блокировки
+func (rt *Runtime) reportLoop(effect *ReactiveEffect) {
+	rt.mu.Lock()
+	if rt.loopReported[effect] {
+		rt.mu.Unlock()
+		return
+	}
+	rt.loopReported[effect] = true
+	effectErr := newEffectError(effect, ErrInfiniteLoop)
+	handler := rt.errorHandler
+	rt.mu.Unlock()
+
+	handler(effectErr)
+}
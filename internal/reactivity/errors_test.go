@@ -0,0 +1,198 @@
+package reactivity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestSelfTriggeringEffect проверяем, что эффект, меняющий прочитанное значение, не зацикливается
+func TestSelfTriggeringEffect(t *testing.T) {
+	rt := NewRuntime()
+	count := NewRefIn(rt, 0)
+	runs := 0
+
+	rt.WatchEffect(func() {
+		runs++
+		count.Set(count.Get() + 1)
+	})
+
+	if runs != 1 || count.Get() != 1 {
+		t.Errorf("Ожидали 1 запуск и значение 1, получили %d и %d", runs, count.Get())
+	}
+}
+
+// TestInfiniteLoopDetection проверяем отчёт о цикле между двумя эффектами
+func TestInfiniteLoopDetection(t *testing.T) {
+	rt := NewRuntime()
+	rt.SetMaxRecursion(10)
+
+	var reported []*EffectError
+	rt.SetErrorHandler(func(err *EffectError) {
+		reported = append(reported, err)
+	})
+
+	ping := NewRefIn(rt, 0)
+	pong := NewRefIn(rt, 0)
+	pingRuns := 0
+
+	rt.WatchEffect(func() {
+		pingRuns++
+		pong.Set(ping.Get() + 1)
+	})
+	rt.WatchEffect(func() {
+		ping.Set(pong.Get() + 1)
+	})
+
+	if len(reported) == 0 {
+		t.Fatal("Ожидали отчёт о бесконечном цикле")
+	}
+
+	err := reported[0]
+	if !errors.Is(err, ErrInfiniteLoop) {
+		t.Errorf("Ожидали ErrInfiniteLoop, получили %v", err.Err)
+	}
+	if err.EffectID == 0 || len(err.Chain) == 0 {
+		t.Errorf("Отчёт должен содержать ID эффекта и цепочку, получили %+v", err)
+	}
+	if !strings.Contains(err.Error(), "*reactivity.Ref[int].value") {
+		t.Errorf("Цепочка должна называть цель и ключ: %s", err.Error())
+	}
+	if pingRuns > 12 {
+		t.Errorf("Цикл должен прерваться около предела, запусков %d", pingRuns)
+	}
+
+	// После прерывания среда снова работоспособна
+	reported = nil
+	other := NewRefIn(rt, 0)
+	otherRuns := 0
+	rt.WatchEffect(func() {
+		other.Get()
+		otherRuns++
+	})
+	other.Set(1)
+	if otherRuns != 2 || len(reported) != 0 {
+		t.Errorf("Среда должна работать после цикла, запусков %d, ошибок %d", otherRuns, len(reported))
+	}
+}
+
+// TestEffectErrorString проверяем текст ошибки
+func TestEffectErrorString(t *testing.T) {
+	err := &EffectError{
+		EffectID: 3,
+		Chain: []TriggerEvent{
+			{Target: "*main.Report", Key: "AllocMB"},
+			{Target: "*main.Report", Key: "SysMB", EffectID: 3},
+		},
+		Err: ErrInfiniteLoop,
+	}
+
+	want := "reactivity: эффект #3: эффект перезапускается бесконечно; цепочка: *main.Report.AllocMB -> *main.Report.SysMB (эффект #3)"
+	if err.Error() != want {
+		t.Errorf("Ожидали %q, получили %q", want, err.Error())
+	}
+}
+
+// TestEffectPanicIsolation проверяем, что паника в эффекте не мешает остальным подписчикам
+func TestEffectPanicIsolation(t *testing.T) {
+	rt := NewRuntime()
+	var reported []*EffectError
+	rt.SetErrorHandler(func(err *EffectError) {
+		reported = append(reported, err)
+	})
+
+	report := &testReport{}
+	failing := rt.WatchEffect(func() {
+		rt.Track(report, "AllocMB")
+		if report.AllocMB != "" {
+			panic("сломалось")
+		}
+	}, WithName("failing"))
+
+	otherRuns := 0
+	rt.WatchEffect(func() {
+		rt.Track(report, "AllocMB")
+		otherRuns++
+	})
+
+	rt.Batch(func() {
+		report.AllocMB = "1.00 MB"
+		rt.Trigger(report, "AllocMB")
+	})
+
+	if otherRuns != 2 {
+		t.Errorf("Второй подписчик должен перезапуститься, запусков %d", otherRuns)
+	}
+	if len(reported) != 1 {
+		t.Fatalf("Ожидали 1 ошибку, получили %d", len(reported))
+	}
+
+	err := reported[0]
+	if !errors.Is(err, ErrEffectPanic) || err.Panic != "сломалось" {
+		t.Errorf("Ожидали панику 'сломалось', получили %v", err)
+	}
+	if err.EffectID != failing.ID || err.EffectName != "failing" {
+		t.Errorf("Неверный эффект в отчёте: %+v", err)
+	}
+	if err.Target != "*reactivity.testReport" || err.Key != "AllocMB" {
+		t.Errorf("Ожидали цель *reactivity.testReport и ключ AllocMB, получили %s.%s", err.Target, err.Key)
+	}
+	if len(err.Stack) == 0 {
+		t.Error("Отчёт должен содержать стек")
+	}
+
+	// Эффект без StopOnPanic продолжает работать
+	if !failing.Active {
+		t.Error("Эффект без StopOnPanic должен оставаться активным")
+	}
+}
+
+// TestStopOnPanic проверяем автоматическую остановку упавшего эффекта
+func TestStopOnPanic(t *testing.T) {
+	rt := NewRuntime()
+	rt.SetErrorHandler(func(err *EffectError) {})
+
+	count := NewRefIn(rt, 0)
+	runs := 0
+	effect := rt.WatchEffect(func() {
+		runs++
+		if count.Get() > 0 {
+			panic("сломалось")
+		}
+	}, WithStopOnPanic())
+
+	count.Set(1)
+	count.Set(2)
+
+	if effect.Active {
+		t.Error("Эффект должен быть остановлен после паники")
+	}
+	if runs != 2 {
+		t.Errorf("Ожидали 2 запуска, получили %d", runs)
+	}
+}
+
+// TestPanicInTriggerGoroutine проверяем, что паника не выходит в горутину, вызвавшую Set
+func TestPanicInTriggerGoroutine(t *testing.T) {
+	rt := NewRuntime()
+	rt.SetErrorHandler(func(err *EffectError) {})
+
+	count := NewRefIn(rt, 0)
+	rt.WatchEffect(func() {
+		if count.Get() > 0 {
+			panic("сломалось")
+		}
+	})
+
+	done := make(chan interface{})
+	go func() {
+		defer func() {
+			done <- recover()
+		}()
+		count.Set(1)
+	}()
+
+	if recovered := <-done; recovered != nil {
+		t.Errorf("Паника не должна выходить из Set, получили %v", recovered)
+	}
+}
@@ -0,0 +1,218 @@
+package reactivity
+
+import (
+	"sync"
+	"time"
+)
+
+// Debouncer Откладывает вызов до тех пор, пока вызовы Call не прекратятся на wait.
+// Выполняется только последняя переданная функция
+type Debouncer struct {
+	clock Clock
+	wait  time.Duration
+
+	mu    sync.Mutex
+	timer Timer
+	fn    func()
+	// seq Номер последнего Call: таймер, сработавший после перезапуска, ничего не делает
+	seq int
+}
+
+// NewDebouncer Конструктор (nil clock — SystemClock)
+func NewDebouncer(wait time.Duration, clock Clock) *Debouncer {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &Debouncer{clock: clock, wait: wait}
+}
+
+// Call Запланировать fn, отменив ранее запланированный вызов
+func (d *Debouncer) Call(fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+
+	d.seq++
+	seq := d.seq
+	d.fn = fn
+	d.timer = d.clock.AfterFunc(d.wait, func() {
+		d.fire(seq)
+	})
+}
+
+func (d *Debouncer) fire(seq int) {
+	d.mu.Lock()
+	if seq != d.seq || d.fn == nil {
+		d.mu.Unlock()
+		return
+	}
+	fn := d.fn
+	d.fn = nil
+	d.timer = nil
+	d.mu.Unlock()
+
+	fn()
+}
+
+// Stop Отменить запланированный вызов
+func (d *Debouncer) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.seq++
+	d.fn = nil
+	d.timer = nil
+}
+
+// Throttler Выполняет вызов не чаще раза в wait: первый сразу,
+// остальные внутри окна схлопываются в один вызов в конце окна
+type Throttler struct {
+	clock Clock
+	wait  time.Duration
+
+	mu      sync.Mutex
+	timer   Timer
+	fn      func()
+	lastRun time.Time
+	seq     int
+}
+
+// NewThrottler Конструктор (nil clock — SystemClock)
+func NewThrottler(wait time.Duration, clock Clock) *Throttler {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &Throttler{clock: clock, wait: wait}
+}
+
+// Call Выполнить fn сразу, если окно свободно, иначе в конце окна
+func (t *Throttler) Call(fn func()) {
+	t.mu.Lock()
+
+	now := t.clock.Now()
+	elapsed := now.Sub(t.lastRun)
+	if t.timer == nil && (t.lastRun.IsZero() || elapsed >= t.wait) {
+		t.lastRun = now
+		t.mu.Unlock()
+		fn()
+		return
+	}
+
+	t.fn = fn
+	if t.timer == nil {
+		seq := t.seq
+		t.timer = t.clock.AfterFunc(t.wait-elapsed, func() {
+			t.fire(seq)
+		})
+	}
+	t.mu.Unlock()
+}
+
+func (t *Throttler) fire(seq int) {
+	t.mu.Lock()
+	if seq != t.seq || t.fn == nil {
+		t.mu.Unlock()
+		return
+	}
+	fn := t.fn
+	t.fn = nil
+	t.timer = nil
+	t.lastRun = t.clock.Now()
+	t.mu.Unlock()
+
+	fn()
+}
+
+// Stop Отменить отложенный вызов
+func (t *Throttler) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	t.seq++
+	t.fn = nil
+	t.timer = nil
+}
+
+// limiter Общий интерфейс Debouncer и Throttler
+type limiter interface {
+	Call(fn func())
+	Stop()
+}
+
+// limitScheduler Планировщик, пропускающий перезапуски каждого эффекта через свой limiter.
+// Часы берутся из среды эффекта
+type limitScheduler struct {
+	mu       sync.Mutex
+	limiters map[*ReactiveEffect]limiter
+	create   func(clock Clock) limiter
+}
+
+// DebounceScheduler Перезапускать эффект только после паузы в срабатываниях длиной wait.
+// Перезапуск выполняется в горутине таймера
+func DebounceScheduler(wait time.Duration) Scheduler {
+	return &limitScheduler{
+		limiters: make(map[*ReactiveEffect]limiter),
+		create: func(clock Clock) limiter {
+			return NewDebouncer(wait, clock)
+		},
+	}
+}
+
+// ThrottleScheduler Перезапускать эффект не чаще раза в wait: первое срабатывание сразу,
+// последующие внутри окна — одним перезапуском в конце окна
+func ThrottleScheduler(wait time.Duration) Scheduler {
+	return &limitScheduler{
+		limiters: make(map[*ReactiveEffect]limiter),
+		create: func(clock Clock) limiter {
+			return NewThrottler(wait, clock)
+		},
+	}
+}
+
+func (s *limitScheduler) Schedule(effect *ReactiveEffect) {
+	s.limiterFor(effect).Call(effect.Run)
+}
+
+func (s *limitScheduler) limiterFor(effect *ReactiveEffect) limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if l, exists := s.limiters[effect]; exists {
+		return l
+	}
+
+	rt := effect.runtime
+	l := s.create(rt.Clock())
+	s.limiters[effect] = l
+
+	// Остановленный эффект не должен держать таймер
+	rt.mu.Lock()
+	effect.OnStop = append(effect.OnStop, func() {
+		l.Stop()
+
+		s.mu.Lock()
+		delete(s.limiters, effect)
+		s.mu.Unlock()
+	})
+	rt.mu.Unlock()
+	return l
+}
+
+// WithDebounce Перезапускать эффект только после паузы в срабатываниях длиной wait
+func WithDebounce(wait time.Duration) EffectOption {
+	return WithScheduler(DebounceScheduler(wait))
+}
+
+// WithThrottle Перезапускать эффект не чаще раза в wait
+func WithThrottle(wait time.Duration) EffectOption {
+	return WithScheduler(ThrottleScheduler(wait))
+}
@@ -0,0 +1,94 @@
+package reactivity
+
+import (
+	"testing"
+	"time"
+)
+
+// TestWithDebounce проверяем, что серия изменений перезапускает эффект один раз после паузы
+func TestWithDebounce(t *testing.T) {
+	rt := NewRuntime()
+	clock := NewFakeClock(time.Unix(0, 0))
+	rt.SetClock(clock)
+
+	alloc := NewRefIn(rt, "0.00 MB")
+	var rendered []string
+
+	rt.WatchEffect(func() {
+		rendered = append(rendered, alloc.Get())
+	}, WithDebounce(100*time.Millisecond))
+
+	alloc.Set("1.00 MB")
+	clock.Advance(50 * time.Millisecond)
+	alloc.Set("2.00 MB")
+	clock.Advance(50 * time.Millisecond)
+	alloc.Set("3.00 MB")
+
+	if len(rendered) != 1 {
+		t.Fatalf("До паузы эффект не должен перезапускаться, получили %v", rendered)
+	}
+
+	clock.Advance(100 * time.Millisecond)
+	if len(rendered) != 2 || rendered[1] != "3.00 MB" {
+		t.Errorf("Ожидали один перезапуск с последним значением, получили %v", rendered)
+	}
+}
+
+// TestWithThrottle проверяем первый перезапуск сразу и один в конце окна
+func TestWithThrottle(t *testing.T) {
+	rt := NewRuntime()
+	clock := NewFakeClock(time.Unix(0, 0))
+	rt.SetClock(clock)
+
+	count := NewRefIn(rt, 0)
+	var rendered []int
+
+	rt.WatchEffect(func() {
+		rendered = append(rendered, count.Get())
+	}, WithThrottle(time.Second))
+
+	count.Set(1)
+	count.Set(2)
+	count.Set(3)
+
+	if len(rendered) != 2 || rendered[1] != 1 {
+		t.Fatalf("Первое изменение должно перезапустить эффект сразу, получили %v", rendered)
+	}
+
+	clock.Advance(999 * time.Millisecond)
+	if len(rendered) != 2 {
+		t.Fatalf("Внутри окна перезапусков быть не должно, получили %v", rendered)
+	}
+
+	clock.Advance(time.Millisecond)
+	if len(rendered) != 3 || rendered[2] != 3 {
+		t.Errorf("В конце окна ожидали перезапуск с последним значением, получили %v", rendered)
+	}
+}
+
+// TestDebounceStop проверяем, что остановка эффекта отменяет отложенный перезапуск
+func TestDebounceStop(t *testing.T) {
+	rt := NewRuntime()
+	clock := NewFakeClock(time.Unix(0, 0))
+	rt.SetClock(clock)
+
+	count := NewRefIn(rt, 0)
+	runs := 0
+
+	effect := rt.WatchEffect(func() {
+		count.Get()
+		runs++
+	}, WithDebounce(time.Second))
+
+	count.Set(1)
+	effect.Stop()
+
+	if clock.Pending() != 0 {
+		t.Errorf("Остановка должна отменить таймер, осталось %d", clock.Pending())
+	}
+
+	clock.Advance(time.Second)
+	if runs != 1 {
+		t.Errorf("Остановленный эффект не должен перезапускаться, запусков: %d", runs)
+	}
+}
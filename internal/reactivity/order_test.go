@@ -0,0 +1,76 @@
+package reactivity
+
+import (
+	"testing"
+)
+
+// TestGlitchFreePropagation проверяем, что читатель производного значения запускается
+// после эффекта, который это значение пишет, и видит согласованные данные
+func TestGlitchFreePropagation(t *testing.T) {
+	rt := NewRuntime()
+	source := NewRefIn(rt, 1)
+	derived := NewRefIn(rt, 0)
+
+	type pair struct{ source, derived int }
+	var seen []pair
+
+	// Читатель создан раньше писателя: при обходе по порядку подписки он увидел бы полуобновлённое состояние
+	rt.WatchEffect(func() {
+		seen = append(seen, pair{source.Get(), derived.Get()})
+	})
+	rt.WatchEffect(func() {
+		derived.Set(source.Get() * 2)
+	})
+
+	seen = seen[:0]
+	source.Set(2)
+
+	if len(seen) != 1 || seen[0] != (pair{2, 4}) {
+		t.Errorf("Ожидали один запуск с [2 4], получили %v", seen)
+	}
+}
+
+// TestComputedDiamond проверяем ромб: два вычисляемых значения от одного источника и эффект от обоих
+func TestComputedDiamond(t *testing.T) {
+	rt := NewRuntime()
+	source := NewRefIn(rt, 1)
+
+	double := NewComputedIn(rt, func() int {
+		return source.Get() * 2
+	})
+	triple := NewComputedIn(rt, func() int {
+		return source.Get() * 3
+	})
+
+	var sums []int
+	rt.WatchEffect(func() {
+		sums = append(sums, double.Get()+triple.Get())
+	})
+
+	source.Set(2)
+
+	if len(sums) != 2 || sums[1] != 10 {
+		t.Errorf("Ожидали [5 10], получили %v", sums)
+	}
+}
+
+// TestEffectHeight проверяем высоту эффектов в графе
+func TestEffectHeight(t *testing.T) {
+	rt := NewRuntime()
+	source := NewRefIn(rt, 1)
+
+	double := NewComputedIn(rt, func() int {
+		return source.Get() * 2
+	})
+	quadruple := NewComputedIn(rt, func() int {
+		return double.Get() * 2
+	})
+
+	effect := rt.WatchEffect(func() {
+		quadruple.Get()
+	})
+
+	if effect.height != 3 {
+		t.Errorf("Ожидали высоту 3 (источник -> double -> quadruple -> эффект), получили %d", effect.height)
+	}
+}
@@ -0,0 +1,124 @@
+package reactivity
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// owner Цикл-владелец: в каждый момент тела эффектов всех сред выполняет одна горутина.
+// Только она видит активный эффект, область и паузу отслеживания своих сред, поэтому
+// чтения и записи из других горутин никого не подписывают и не принимаются за запись
+// эффекта в самого себя.
+//
+// В Go нет состояния горутины, поэтому владелец узнаёт себя по кадру ownerCall на своём стеке:
+// через него вызывается весь код, который владелец выполняет от имени цикла. Стек проверяется
+// (runtime.Callers) только пока цикл кем-то занят, а внутри эффекта поиск останавливается
+// на ближайшем кадре ownerCall — у вызова тела эффекта
+var owner struct {
+	mu   sync.Mutex
+	free *sync.Cond
+	busy atomic.Bool
+	// pending Среды, чьи очереди пополнились, пока цикл был занят; владелец разбирает их перед уходом
+	pending []*Runtime
+}
+
+// ownerPC Адрес возврата из вызова fn внутри ownerCall: по нему владелец находит свой кадр
+var ownerPC uintptr
+
+func init() {
+	owner.free = sync.NewCond(&owner.mu)
+
+	ownerCall(func() {
+		var pcs [1]uintptr
+		runtime.Callers(2, pcs[:])
+		ownerPC = pcs[0]
+	})
+}
+
+// ownerCall Выполнить fn от имени цикла. Вызывается только владельцем
+//
+//go:noinline
+func ownerCall(fn func()) {
+	fn()
+}
+
+// isOwner Выполняет ли вызывающая горутина эффекты, то есть есть ли на её стеке кадр ownerCall
+func isOwner() bool {
+	if !owner.busy.Load() {
+		return false
+	}
+
+	var pcs [32]uintptr
+	for skip := 2; ; {
+		n := runtime.Callers(skip, pcs[:])
+		for _, pc := range pcs[:n] {
+			if pc == ownerPC {
+				return true
+			}
+		}
+		if n < len(pcs) {
+			return false
+		}
+		skip += n
+	}
+}
+
+// runOwned Выполнить fn владельцем. Горутина, которая им ещё не является, ждёт, пока цикл
+// освободится, и перед уходом разбирает очереди, пополненные другими горутинами
+func runOwned(fn func()) {
+	if isOwner() {
+		fn()
+		return
+	}
+
+	owner.mu.Lock()
+	for owner.busy.Load() {
+		owner.free.Wait()
+	}
+	owner.busy.Store(true)
+	owner.mu.Unlock()
+
+	defer releaseOwner()
+	ownerCall(fn)
+}
+
+// tryOwn Занять свободный цикл. Если он занят, среда откладывается до ухода владельца
+func tryOwn(rt *Runtime) bool {
+	owner.mu.Lock()
+	defer owner.mu.Unlock()
+
+	if owner.busy.Load() {
+		for _, pending := range owner.pending {
+			if pending == rt {
+				return false
+			}
+		}
+		owner.pending = append(owner.pending, rt)
+		return false
+	}
+	owner.busy.Store(true)
+	return true
+}
+
+// releaseOwner Разобрать отложенные среды и освободить цикл
+func releaseOwner() {
+	for {
+		owner.mu.Lock()
+		pending := owner.pending
+		owner.pending = nil
+		if len(pending) == 0 {
+			owner.busy.Store(false)
+			owner.free.Broadcast()
+			owner.mu.Unlock()
+			return
+		}
+		owner.mu.Unlock()
+
+		ownerCall(func() {
+			for _, rt := range pending {
+				rt.drain()
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package reactivity
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestConcurrentTrackTrigger проверяем Track/Trigger из множества горутин (запускать с -race)
+func TestConcurrentTrackTrigger(t *testing.T) {
+	const goroutines = 16
+	const iterations = 200
+
+	rt := NewRuntime()
+	shared := &testCounter{}
+	var sharedRuns atomic.Int32
+
+	rt.WatchEffect(func() {
+		rt.Track(shared, "Value")
+		sharedRuns.Add(1)
+	})
+
+	var ownRuns atomic.Int32
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			own := &testCounter{}
+			effect := rt.WatchEffect(func() {
+				rt.Track(own, "Value")
+				ownRuns.Add(1)
+			})
+			for i := 0; i < iterations; i++ {
+				rt.Trigger(own, "Value")
+				rt.Trigger(shared, "Value")
+				// Чтение вне эффекта
+				rt.Track(shared, "Value")
+			}
+			effect.Stop()
+		}()
+	}
+	wg.Wait()
+
+	// Каждый Trigger своего ключа доходит до эффекта: Trigger не ждёт, поэтому соседние
+	// срабатывания могут слиться в один запуск, но последний запуск всегда после последнего Trigger
+	if ownRuns.Load() < goroutines*2 {
+		t.Errorf("Эффекты горутин должны перезапускаться, запусков %d", ownRuns.Load())
+	}
+
+	if sharedRuns.Load() < 2 {
+		t.Errorf("Общий эффект должен перезапускаться, запусков %d", sharedRuns.Load())
+	}
+
+	// Очередь разобрана, и после гонки граф в порядке: один Trigger — один перезапуск
+	before := sharedRuns.Load()
+	rt.Trigger(shared, "Value")
+	if got := sharedRuns.Load() - before; got != 1 {
+		t.Errorf("Ожидали 1 перезапуск общего эффекта, получили %d", got)
+	}
+}
+
+// testCell Число под Track/Trigger: запись и чтение атомарны, поэтому ячейку можно
+// трогать из любой горутины
+type testCell struct {
+	runtime *Runtime
+	value   atomic.Int64
+}
+
+func newTestCell(rt *Runtime) *testCell {
+	return &testCell{runtime: rt}
+}
+
+func (c *testCell) Get() int64 {
+	c.runtime.Track(c, "value")
+	return c.value.Load()
+}
+
+func (c *testCell) Set(value int64) {
+	if c.value.Swap(value) != value {
+		c.runtime.Trigger(c, "value")
+	}
+}
+
+// TestTriggerDuringFlush проверяем, что Trigger во время чужого сброса не ждёт,
+// а эффект запускает горутина-владелец очереди
+func TestTriggerDuringFlush(t *testing.T) {
+	rt := NewRuntime()
+	blocker := newTestCell(rt)
+	other := newTestCell(rt)
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	rt.WatchEffect(func() {
+		if blocker.Get() == 1 {
+			close(entered)
+			<-release
+		}
+	})
+
+	var otherRuns atomic.Int32
+	rt.WatchEffect(func() {
+		other.Get()
+		otherRuns.Add(1)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		blocker.Set(1)
+	}()
+	<-entered
+
+	other.Set(1)
+	if otherRuns.Load() != 1 {
+		t.Errorf("Эффект должен ждать владельца очереди, запусков %d", otherRuns.Load())
+	}
+
+	close(release)
+	<-done
+	if otherRuns.Load() != 2 {
+		t.Errorf("Владелец должен запустить эффект, поставленный другой горутиной, запусков %d", otherRuns.Load())
+	}
+}
+
+// TestConcurrentComputed проверяем чтение вычисляемого значения из нескольких горутин
+func TestConcurrentComputed(t *testing.T) {
+	rt := NewRuntime()
+	counter := &testCounter{Value: 1}
+
+	double := NewComputedIn(rt, func() int {
+		rt.Track(counter, "Value")
+		return counter.Value * 2
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if got := double.Get(); got != 2 {
+					t.Errorf("Ожидали 2, получили %d", got)
+					return
+				}
+				rt.Trigger(counter, "Value")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// blockingEffect Эффект, который при первом запуске после blocker.Set(1) сообщает об этом
+// в entered и ждёт release. before и after выполняются до и после ожидания
+func blockingEffect(rt *Runtime, blocker *testCell, before, after func()) (entered, release chan struct{}) {
+	entered = make(chan struct{})
+	release = make(chan struct{})
+	blocked := false
+	rt.WatchEffect(func() {
+		before()
+		if blocker.Get() == 1 && !blocked {
+			blocked = true
+			close(entered)
+			<-release
+		}
+		after()
+	})
+	return entered, release
+}
+
+// TestSetFromOtherGoroutineDuringRun проверяем, что запись из другой горутины во время
+// запуска эффекта не принимается за запись эффекта в самого себя
+func TestSetFromOtherGoroutineDuringRun(t *testing.T) {
+	rt := NewRuntime()
+	blocker := newTestCell(rt)
+	value := newTestCell(rt)
+
+	var runs atomic.Int32
+	entered, release := blockingEffect(rt, blocker, func() {
+		value.Get()
+	}, func() {
+		runs.Add(1)
+	})
+
+	go func() {
+		<-entered
+		value.Set(1)
+		close(release)
+	}()
+	blocker.Set(1)
+
+	if runs.Load() != 3 {
+		t.Errorf("Запись во время запуска должна перезапустить эффект, запусков %d", runs.Load())
+	}
+}
+
+// TestReadFromOtherGoroutineDuringRun проверяем, что чтение из другой горутины во время
+// запуска эффекта не подписывает его
+func TestReadFromOtherGoroutineDuringRun(t *testing.T) {
+	rt := NewRuntime()
+	blocker := newTestCell(rt)
+	unrelated := newTestCell(rt)
+
+	var runs atomic.Int32
+	entered, release := blockingEffect(rt, blocker, func() {}, func() {
+		runs.Add(1)
+	})
+
+	go func() {
+		<-entered
+		unrelated.Get()
+		close(release)
+	}()
+	blocker.Set(1)
+
+	unrelated.Set(1)
+	if runs.Load() != 2 {
+		t.Errorf("Чужое чтение не должно подписывать эффект, запусков %d", runs.Load())
+	}
+}
+
+// TestPauseFromOtherGoroutineDuringRun проверяем, что PauseTracking в другой горутине
+// не выключает отслеживание в запущенном эффекте
+func TestPauseFromOtherGoroutineDuringRun(t *testing.T) {
+	rt := NewRuntime()
+	blocker := newTestCell(rt)
+	late := newTestCell(rt)
+
+	var runs atomic.Int32
+	entered, release := blockingEffect(rt, blocker, func() {}, func() {
+		late.Get()
+		runs.Add(1)
+	})
+
+	finished := make(chan struct{})
+	resumed := make(chan struct{})
+	go func() {
+		defer close(resumed)
+		<-entered
+		rt.PauseTracking()
+		close(release)
+		<-finished
+		rt.ResumeTracking()
+	}()
+	blocker.Set(1)
+	close(finished)
+	<-resumed
+
+	late.Set(1)
+	if runs.Load() != 3 {
+		t.Errorf("Чтение после чужой паузы должно подписывать эффект, запусков %d", runs.Load())
+	}
+}
+
+// TestScopeFromOtherGoroutineDuringRun проверяем, что EffectScope.Run в другой горутине
+// не забирает эффекты, созданные в запущенном эффекте
+func TestScopeFromOtherGoroutineDuringRun(t *testing.T) {
+	rt := NewRuntime()
+	blocker := newTestCell(rt)
+	scope := rt.NewEffectScope()
+
+	var inner *ReactiveEffect
+	innerCreated := make(chan struct{})
+	entered, release := blockingEffect(rt, blocker, func() {}, func() {
+		if blocker.Get() == 1 && inner == nil {
+			inner = rt.WatchEffect(func() {})
+			close(innerCreated)
+		}
+	})
+
+	scoped := make(chan *ReactiveEffect, 1)
+	go func() {
+		<-entered
+		go scope.Run(func() {
+			<-innerCreated
+			scoped <- rt.WatchEffect(func() {})
+		})
+		// Даём области начаться, пока эффект ещё идёт
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+	}()
+	blocker.Set(1)
+	own := <-scoped
+
+	scope.Stop()
+	if own.Active || !inner.Active {
+		t.Errorf("Область должна остановить только свой эффект: свой %v, чужой %v", own.Active, inner.Active)
+	}
+}
@@ -0,0 +1,239 @@
+package reactivity
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// refValueKey Ключ, под которым Ref отслеживает своё значение
+const refValueKey = "value"
+
+// Ref Типизированное реактивное значение.
+// Get подписывает активный эффект, Set перезапускает подписчиков
+type Ref[T any] struct {
+	runtime *Runtime
+	value   T
+	// version Номер записи, по которому Update замечает конкурентный Set
+	version int
+}
+
+// NewRef Конструктор в среде по умолчанию
+func NewRef[T any](value T) *Ref[T] {
+	return NewRefIn(defaultRuntime, value)
+}
+
+// NewRefIn Конструктор в заданной среде
+func NewRefIn[T any](rt *Runtime, value T) *Ref[T] {
+	return &Ref[T]{
+		runtime: rt,
+		value:   value,
+	}
+}
+
+// Get Получить значение
+func (r *Ref[T]) Get() T {
+	r.runtime.mu.Lock()
+	defer r.runtime.unlock()
+
+	r.runtime.trackLocked(r, refValueKey)
+	return r.value
+}
+
+// Set Установить значение. Одинаковое значение подписчиков не будит
+func (r *Ref[T]) Set(value T) {
+	r.runtime.mu.Lock()
+	defer r.runtime.unlock()
+
+	r.setLocked(value)
+}
+
+func (r *Ref[T]) setLocked(value T) {
+	if reflect.DeepEqual(r.value, value) {
+		return
+	}
+
+	r.value = value
+	r.version++
+	r.runtime.triggerLocked(r, TriggerSet, refValueKey)
+}
+
+// Update Установить значение, вычисленное из текущего. fn вызывается вне блокировки:
+// если значение успели изменить из другой горутины, fn вызывается снова с новым значением
+func (r *Ref[T]) Update(fn func(current T) T) {
+	rt := r.runtime
+	for {
+		rt.mu.Lock()
+		current, version := r.value, r.version
+		rt.mu.Unlock()
+
+		value := fn(current)
+
+		rt.mu.Lock()
+		if r.version == version {
+			r.setLocked(value)
+			rt.unlock()
+			return
+		}
+		rt.mu.Unlock()
+	}
+}
+
+// Reactive Типизированная реактивная обёртка над структурой.
+// Каждое поле отслеживается отдельно под своим именем
+type Reactive[T any] struct {
+	runtime *Runtime
+	value   T
+	fields  []string
+	version int
+}
+
+// NewReactive Конструктор в среде по умолчанию. T должен быть структурой
+func NewReactive[T any](value T) *Reactive[T] {
+	return NewReactiveIn(defaultRuntime, value)
+}
+
+// NewReactiveIn Конструктор в заданной среде
+func NewReactiveIn[T any](rt *Runtime, value T) *Reactive[T] {
+	valueType := reflect.TypeFor[T]()
+	if valueType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("reactivity: Reactive ожидает структуру, получено %s", valueType))
+	}
+
+	fields := make([]string, 0, valueType.NumField())
+	for i := 0; i < valueType.NumField(); i++ {
+		fields = append(fields, valueType.Field(i).Name)
+	}
+
+	return &Reactive[T]{
+		runtime: rt,
+		value:   value,
+		fields:  fields,
+	}
+}
+
+// Get Получить копию структуры, подписавшись на все её поля
+func (r *Reactive[T]) Get() T {
+	r.runtime.mu.Lock()
+	defer r.runtime.unlock()
+
+	for _, name := range r.fields {
+		r.runtime.trackLocked(r, name)
+	}
+	return r.value
+}
+
+// Set Заменить структуру целиком. Перезапускаются подписчики только изменившихся полей
+func (r *Reactive[T]) Set(value T) {
+	r.runtime.mu.Lock()
+	defer r.runtime.unlock()
+
+	r.setLocked(value)
+}
+
+// setLocked Все изменившиеся поля оповещаются под одной блокировкой, то есть одной транзакцией
+func (r *Reactive[T]) setLocked(value T) {
+	oldValue := reflect.ValueOf(&r.value).Elem()
+	newValue := reflect.ValueOf(&value).Elem()
+
+	changed := make([]string, 0)
+	for i, name := range r.fields {
+		if !reflect.DeepEqual(fieldInterface(oldValue, i), fieldInterface(newValue, i)) {
+			changed = append(changed, name)
+		}
+	}
+
+	if len(changed) == 0 {
+		return
+	}
+
+	r.value = value
+	r.version++
+	r.runtime.triggerLocked(r, TriggerSet, changed...)
+}
+
+// Update Изменить структуру на месте. Перезапускаются подписчики только изменившихся полей.
+// fn вызывается вне блокировки и повторно, если структуру успели изменить из другой горутины
+func (r *Reactive[T]) Update(fn func(value *T)) {
+	rt := r.runtime
+	for {
+		rt.mu.Lock()
+		value, version := r.value, r.version
+		rt.mu.Unlock()
+
+		fn(&value)
+
+		rt.mu.Lock()
+		if r.version == version {
+			r.setLocked(value)
+			rt.unlock()
+			return
+		}
+		rt.mu.Unlock()
+	}
+}
+
+// fieldInterface Значение поля адресуемой структуры, в том числе неэкспортируемого
+func fieldInterface(value reflect.Value, i int) interface{} {
+	field := value.Field(i)
+	return reflect.NewAt(field.Type(), field.Addr().UnsafePointer()).Elem().Interface()
+}
+
+// FieldRef Типизированная ссылка на одно поле Reactive
+type FieldRef[T, V any] struct {
+	parent   *Reactive[T]
+	name     string
+	selector func(value *T) *V
+}
+
+// Field Получить ссылку на поле, выбранное селектором вида func(r *Report) *string { return &r.AllocMB }.
+// Имя и тип поля проверяются компилятором; селектор должен возвращать адрес поля верхнего уровня
+func Field[T, V any](r *Reactive[T], selector func(value *T) *V) *FieldRef[T, V] {
+	var probe T
+	fieldPtr := unsafe.Pointer(selector(&probe))
+	probeValue := reflect.ValueOf(&probe).Elem()
+
+	for i, name := range r.fields {
+		field := probeValue.Field(i)
+		if field.Addr().UnsafePointer() == fieldPtr && field.Type() == reflect.TypeFor[V]() {
+			return &FieldRef[T, V]{
+				parent:   r,
+				name:     name,
+				selector: selector,
+			}
+		}
+	}
+
+	panic(fmt.Sprintf("reactivity: селектор должен возвращать адрес поля верхнего уровня %s", probeValue.Type()))
+}
+
+// Name Имя поля
+func (f *FieldRef[T, V]) Name() string {
+	return f.name
+}
+
+// Get Получить значение поля, подписавшись только на него
+func (f *FieldRef[T, V]) Get() V {
+	rt := f.parent.runtime
+	rt.mu.Lock()
+	defer rt.unlock()
+
+	rt.trackLocked(f.parent, f.name)
+	return *f.selector(&f.parent.value)
+}
+
+// Set Установить значение поля
+func (f *FieldRef[T, V]) Set(value V) {
+	rt := f.parent.runtime
+	rt.mu.Lock()
+	defer rt.unlock()
+
+	field := f.selector(&f.parent.value)
+	if reflect.DeepEqual(*field, value) {
+		return
+	}
+
+	*field = value
+	f.parent.version++
+	rt.triggerLocked(f.parent, TriggerSet, f.name)
+}
@@ -0,0 +1,133 @@
+package reactivity
+
+import (
+	"testing"
+)
+
+type testDashboard struct {
+	AllocMB string
+	NumGC   int
+	labels  []string
+}
+
+// TestRef проверяем чтение и запись типизированного значения
+func TestRef(t *testing.T) {
+	count := NewRef(1)
+	var seen []int
+
+	WatchEffect(func() {
+		seen = append(seen, count.Get())
+	})
+
+	count.Set(2)
+	count.Set(2)
+	count.Update(func(current int) int {
+		return current * 10
+	})
+
+	if len(seen) != 3 || seen[0] != 1 || seen[1] != 2 || seen[2] != 20 {
+		t.Errorf("Ожидали [1 2 20], получили %v", seen)
+	}
+}
+
+// TestRefWithComputed проверяем вычисляемое значение поверх Ref
+func TestRefWithComputed(t *testing.T) {
+	heap := NewRef(100)
+	start := heap.Get()
+
+	deviation := NewComputed(func() int {
+		return heap.Get() - start
+	})
+
+	heap.Set(130)
+
+	if got := deviation.Get(); got != 30 {
+		t.Errorf("Ожидали 30, получили %d", got)
+	}
+}
+
+// TestReactiveSet проверяем, что Set будит подписчиков только изменившихся полей
+func TestReactiveSet(t *testing.T) {
+	dashboard := NewReactive(testDashboard{AllocMB: "0.00 MB"})
+	allocRuns, gcRuns, wholeRuns := 0, 0, 0
+
+	alloc := Field(dashboard, func(d *testDashboard) *string { return &d.AllocMB })
+	numGC := Field(dashboard, func(d *testDashboard) *int { return &d.NumGC })
+
+	WatchEffect(func() {
+		alloc.Get()
+		allocRuns++
+	})
+	WatchEffect(func() {
+		numGC.Get()
+		gcRuns++
+	})
+	WatchEffect(func() {
+		dashboard.Get()
+		wholeRuns++
+	})
+
+	dashboard.Set(testDashboard{AllocMB: "1.00 MB"})
+
+	if allocRuns != 2 {
+		t.Errorf("Ожидали 2 запуска эффекта AllocMB, получили %d", allocRuns)
+	}
+	if gcRuns != 1 {
+		t.Errorf("Эффект NumGC не должен перезапускаться, запусков %d", gcRuns)
+	}
+	if wholeRuns != 2 {
+		t.Errorf("Ожидали 2 запуска эффекта всей структуры, получили %d", wholeRuns)
+	}
+}
+
+// TestReactiveUpdate проверяем изменение нескольких полей одной транзакцией
+func TestReactiveUpdate(t *testing.T) {
+	dashboard := NewReactive(testDashboard{})
+	runs := 0
+
+	WatchEffect(func() {
+		dashboard.Get()
+		runs++
+	})
+
+	dashboard.Update(func(d *testDashboard) {
+		d.AllocMB = "2.00 MB"
+		d.NumGC = 3
+	})
+
+	if runs != 2 {
+		t.Errorf("Ожидали 2 запуска, получили %d", runs)
+	}
+	if got := dashboard.Get(); got.AllocMB != "2.00 MB" || got.NumGC != 3 {
+		t.Errorf("Поля не обновились: %+v", got)
+	}
+}
+
+// TestFieldRef проверяем типизированный доступ к полю
+func TestFieldRef(t *testing.T) {
+	dashboard := NewReactive(testDashboard{})
+	numGC := Field(dashboard, func(d *testDashboard) *int { return &d.NumGC })
+	labels := Field(dashboard, func(d *testDashboard) *[]string { return &d.labels })
+
+	if numGC.Name() != "NumGC" || labels.Name() != "labels" {
+		t.Errorf("Неверные имена полей: %s, %s", numGC.Name(), labels.Name())
+	}
+
+	numGC.Set(5)
+	if dashboard.Get().NumGC != 5 {
+		t.Error("Set через FieldRef должен менять структуру")
+	}
+}
+
+// TestFieldPanicsOnNested проверяем, что селектор не на поле структуры отвергается
+func TestFieldPanicsOnNested(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Ожидали панику для селектора, не указывающего на поле")
+		}
+	}()
+
+	dashboard := NewReactive(testDashboard{})
+	outside := 0
+	Field(dashboard, func(d *testDashboard) *int { return &outside })
+}
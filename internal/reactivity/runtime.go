@@ -0,0 +1,184 @@
+package reactivity
+
+import (
+	"sync"
+	"weak"
+)
+
+// Runtime Независимая реактивная среда: собственный граф зависимостей,
+// стек эффектов, очередь перезапусков и последовательности ID.
+// Две среды в одном процессе не видят эффекты и зависимости друг друга.
+//
+// Модель конкурентности: mu защищает граф и состояние среды. Публичные методы захватывают
+// его один раз, внутри пользуются помощниками *Locked и отпускают блокировку перед вызовом
+// пользовательского кода (тела эффектов, геттеры, планировщики, обработчики), поэтому из
+// эффектов можно свободно читать и менять значения. Тела эффектов выполняет одна
+// горутина-владелец цикла (см. owner.go): Trigger из других горутин только ставит эффекты
+// в очередь, и их запускает владелец, а Run ждёт, пока цикл освободится. Активный эффект,
+// текущая область и пауза отслеживания принадлежат владельцу: чтения и записи из других
+// горутин никого не подписывают и не считаются записью эффекта в самого себя
+type Runtime struct {
+	mu           sync.Mutex
+	activeEffect *ReactiveEffect
+	activeScope  *EffectScope
+	shouldTrack  bool
+	trackStack   []bool
+	effectIdSeq  int
+	depIdSeq     int
+	effectStack  []runFrame
+	targetMap    TargetMap
+	cleanups     map[weak.Pointer[byte]]bool
+	// hooks Отладочные обработчики, накопленные под блокировкой (см. unlock)
+	hooks []func()
+
+	// Очередь перезапусков (см. scheduler.go)
+	batchDepth int
+	flushing   bool
+	queue      effectQueue
+	queueSeq   int
+	queued     map[*ReactiveEffect]bool
+	postQueue  []*ReactiveEffect
+	postQueued map[*ReactiveEffect]bool
+
+	// Защита от бесконечных циклов (см. errors.go)
+	maxRecursion int
+	flushRuns    map[*ReactiveEffect]int
+	loopReported map[*ReactiveEffect]bool
+	errorHandler ErrorHandler
+
+	// Часы для отложенных перезапусков (см. clock.go)
+	clock Clock
+}
+
+// NewRuntime Конструктор
+func NewRuntime() *Runtime {
+	return &Runtime{
+		shouldTrack: true,
+		trackStack:  make([]bool, 0),
+		effectStack: make([]runFrame, 0),
+		targetMap:   make(TargetMap),
+		cleanups:    make(map[weak.Pointer[byte]]bool),
+		queue:       make(effectQueue, 0),
+		queued:      make(map[*ReactiveEffect]bool),
+		postQueue:   make([]*ReactiveEffect, 0),
+		postQueued:  make(map[*ReactiveEffect]bool),
+
+		maxRecursion: DefaultMaxRecursion,
+		flushRuns:    make(map[*ReactiveEffect]int),
+		loopReported: make(map[*ReactiveEffect]bool),
+		errorHandler: LogErrorHandler,
+
+		clock: SystemClock,
+	}
+}
+
+// DefaultRuntime Среда по умолчанию, общая для свободных функций пакета
+func DefaultRuntime() *Runtime {
+	return defaultRuntime
+}
+
+// NewDep Создать зависимость в этой среде
+func (rt *Runtime) NewDep() *Dep {
+	rt.mu.Lock()
+	defer rt.unlock()
+
+	return rt.newDepLocked()
+}
+
+func (rt *Runtime) newDepLocked() *Dep {
+	rt.depIdSeq++
+	return &Dep{
+		ID:          rt.depIdSeq,
+		Subscribers: make([]*ReactiveEffect, 0),
+		subsMap:     make(map[*ReactiveEffect]bool),
+		runtime:     rt,
+	}
+}
+
+// NewReactiveEffect Создать эффект в этой среде, не запуская его
+func (rt *Runtime) NewReactiveEffect(fn EffectFunc, opts ...EffectOption) *ReactiveEffect {
+	effect := &ReactiveEffect{
+		Fn:      fn,
+		Deps:    make([]*Dep, 0),
+		Active:  true,
+		runtime: rt,
+	}
+
+	for _, opt := range opts {
+		opt(effect)
+	}
+
+	rt.mu.Lock()
+	defer rt.unlock()
+
+	rt.effectIdSeq++
+	effect.ID = rt.effectIdSeq
+	if scope := rt.currentScopeLocked(); scope != nil {
+		scope.addEffect(effect)
+	}
+
+	return effect
+}
+
+// unlock Отпустить блокировку, вызвать накопленные под ней отладочные обработчики
+// и, если в очереди есть эффекты и её никто не разбирает, разобрать её (см. flush)
+func (rt *Runtime) unlock() {
+	hooks := rt.hooks
+	rt.hooks = nil
+	pending := rt.canFlushLocked()
+	rt.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+	if pending {
+		rt.flush()
+	}
+}
+
+// WatchEffect Создать эффект в этой среде и сразу запустить его
+func (rt *Runtime) WatchEffect(fn EffectFunc, opts ...EffectOption) *ReactiveEffect {
+	effect := rt.NewReactiveEffect(fn, opts...)
+	effect.Run()
+	return effect
+}
+
+// NewDep Создать зависимость в среде по умолчанию
+func NewDep() *Dep {
+	return defaultRuntime.NewDep()
+}
+
+// NewReactiveEffect Создать эффект в среде по умолчанию, не запуская его
+func NewReactiveEffect(fn EffectFunc, opts ...EffectOption) *ReactiveEffect {
+	return defaultRuntime.NewReactiveEffect(fn, opts...)
+}
+
+// WatchEffect Создать эффект и сразу запустить его. Последующие перезапуски идут через планировщик
+func WatchEffect(fn EffectFunc, opts ...EffectOption) *ReactiveEffect {
+	return defaultRuntime.WatchEffect(fn, opts...)
+}
+
+// Track Подписать активный эффект среды по умолчанию на ключ цели
+func Track(target interface{}, key string) {
+	defaultRuntime.Track(target, key)
+}
+
+// Trigger Перезапустить эффекты среды по умолчанию, подписанные на ключ цели
+func Trigger(target interface{}, key string) {
+	defaultRuntime.Trigger(target, key)
+}
+
+// Untrack Забыть цель в среде по умолчанию
+func Untrack(target interface{}) {
+	defaultRuntime.Untrack(target)
+}
+
+// Batch Выполнить fn как транзакцию в среде по умолчанию
+func Batch(fn func()) {
+	defaultRuntime.Batch(fn)
+}
+
+// GetTargetMapStats Статистика графа среды по умолчанию
+func GetTargetMapStats() (targets int, totalDeps int, totalEffects int) {
+	return defaultRuntime.GetTargetMapStats()
+}
@@ -0,0 +1,86 @@
+package reactivity
+
+import (
+	"testing"
+)
+
+// TestRuntimeIsolation проверяем, что две среды не делят граф зависимостей
+func TestRuntimeIsolation(t *testing.T) {
+	first := NewRuntime()
+	second := NewRuntime()
+	counter := &testCounter{}
+
+	firstRuns, secondRuns := 0, 0
+	first.WatchEffect(func() {
+		first.Track(counter, "Value")
+		firstRuns++
+	})
+	second.WatchEffect(func() {
+		second.Track(counter, "Value")
+		secondRuns++
+	})
+
+	first.Trigger(counter, "Value")
+
+	if firstRuns != 2 {
+		t.Errorf("Ожидали 2 запуска в первой среде, получили %d", firstRuns)
+	}
+	if secondRuns != 1 {
+		t.Errorf("Trigger первой среды не должен задевать вторую, запусков %d", secondRuns)
+	}
+
+	// Среда по умолчанию тоже ничего не знает об этих эффектах
+	Trigger(counter, "Value")
+	if firstRuns != 2 || secondRuns != 1 {
+		t.Error("Trigger среды по умолчанию не должен задевать другие среды")
+	}
+}
+
+// TestRuntimeIDSequences проверяем, что последовательности ID у сред свои
+func TestRuntimeIDSequences(t *testing.T) {
+	first := NewRuntime()
+	second := NewRuntime()
+
+	first.NewReactiveEffect(func() {})
+	effect := second.NewReactiveEffect(func() {})
+	dep := second.NewDep()
+
+	if effect.ID != 1 {
+		t.Errorf("Ожидали ID эффекта 1, получили %d", effect.ID)
+	}
+	if dep.ID != 1 {
+		t.Errorf("Ожидали ID зависимости 1, получили %d", dep.ID)
+	}
+	if effect.Runtime() != second {
+		t.Error("Эффект должен принадлежать своей среде")
+	}
+}
+
+// TestComputedIn проверяем вычисляемое значение в отдельной среде
+func TestComputedIn(t *testing.T) {
+	rt := NewRuntime()
+	counter := &testCounter{Value: 1}
+
+	double := NewComputedIn(rt, func() int {
+		rt.Track(counter, "Value")
+		return counter.Value * 2
+	})
+
+	var seen int
+	rt.WatchEffect(func() {
+		seen = double.Get()
+	})
+
+	rt.Batch(func() {
+		counter.Value = 4
+		rt.Trigger(counter, "Value")
+	})
+
+	if seen != 8 {
+		t.Errorf("Ожидали 8, получили %d", seen)
+	}
+
+	if targets, _, _ := rt.GetTargetMapStats(); targets != 1 {
+		t.Errorf("Ожидали 1 цель в среде, получили %d", targets)
+	}
+}
@@ -0,0 +1,256 @@
+package reactivity
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// Scheduler Планировщик перезапусков эффекта.
+// Schedule вызывается вне блокировки графа владельцем цикла, разбирающим очередь
+type Scheduler interface {
+	Schedule(effect *ReactiveEffect)
+}
+
+// SchedulerFunc Адаптер обычной функции к интерфейсу Scheduler
+type SchedulerFunc func(effect *ReactiveEffect)
+
+func (f SchedulerFunc) Schedule(effect *ReactiveEffect) {
+	f(effect)
+}
+
+// SyncScheduler Перезапускает эффект сразу при сбросе очереди (по умолчанию)
+var SyncScheduler Scheduler = SchedulerFunc(func(effect *ReactiveEffect) {
+	effect.Run()
+})
+
+// QueueScheduler Аналог microtask: откладывает эффект до конца текущего сброса,
+// когда все синхронные эффекты уже отработали
+var QueueScheduler Scheduler = SchedulerFunc(func(effect *ReactiveEffect) {
+	rt := effect.runtime
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if !rt.postQueued[effect] {
+		rt.postQueued[effect] = true
+		rt.postQueue = append(rt.postQueue, effect)
+	}
+})
+
+// Batch Выполнить fn как транзакцию: эффекты, задетые внутри, перезапускаются
+// один раз после выхода из самого внешнего Batch
+func (rt *Runtime) Batch(fn func()) {
+	rt.mu.Lock()
+	rt.batchDepth++
+	rt.mu.Unlock()
+
+	defer func() {
+		rt.mu.Lock()
+		rt.batchDepth--
+		rt.unlock()
+	}()
+
+	fn()
+}
+
+// queueEffect Поставить эффект в очередь без дублей, запомнив цепочку срабатываний
+func (rt *Runtime) queueEffect(effect *ReactiveEffect, event TriggerEvent, active *ReactiveEffect) {
+	if !rt.queued[effect] {
+		rt.queued[effect] = true
+		rt.queueSeq++
+		effect.queueSeq = rt.queueSeq
+		heap.Push(&rt.queue, effect)
+		effect.cause = causeChain(event, active)
+	}
+}
+
+// canFlushLocked Есть ли в очереди эффекты, которые можно разобрать прямо сейчас:
+// вне Batch и пока очередь не разбирает другой владелец
+func (rt *Runtime) canFlushLocked() bool {
+	return !rt.flushing && rt.batchDepth == 0 && (len(rt.queue) > 0 || len(rt.postQueue) > 0)
+}
+
+// flush Разобрать очередь, если её никто не разбирает. Горутина, заставшая цикл свободным,
+// становится владельцем и запускает эффекты, в том числе поставленные в очередь другими
+// горутинами во время разбора; остальные горутины только пополняют очередь и не ждут:
+// среду разберёт нынешний владелец перед уходом (см. owner.go)
+func (rt *Runtime) flush() {
+	if isOwner() {
+		rt.drain()
+		return
+	}
+	if !tryOwn(rt) {
+		return
+	}
+
+	defer releaseOwner()
+	ownerCall(rt.drain)
+}
+
+// drain Разобрать очередь владельцем цикла, если её не разбирают выше по стеку
+func (rt *Runtime) drain() {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if rt.canFlushLocked() {
+		rt.flushing = true
+		rt.drainLocked()
+	}
+}
+
+// drainLocked Отдать накопленные эффекты их планировщикам в порядке высоты в графе:
+// сначала те, что пишут в зависимости, потом те, что их читают. Так каждый эффект
+// видит согласованные входные данные и запускается один раз за изменение.
+// Эффекты, задетые во время сброса, попадают в ту же очередь.
+// Вызывается владельцем цикла под блокировкой, на время запусков отпускает её
+// и в конце снимает со среды признак разбора
+func (rt *Runtime) drainLocked() {
+	defer func() {
+		rt.flushing = false
+		clear(rt.flushRuns)
+		clear(rt.loopReported)
+	}()
+
+	for rt.batchDepth == 0 && (len(rt.queue) > 0 || len(rt.postQueue) > 0) {
+		for len(rt.queue) > 0 {
+			effect := heap.Pop(&rt.queue).(*ReactiveEffect)
+			delete(rt.queued, effect)
+
+			rt.unlock()
+			rt.schedule(effect)
+			rt.mu.Lock()
+		}
+
+		if len(rt.postQueue) > 0 {
+			jobs := rt.postQueue
+			rt.postQueue = make([]*ReactiveEffect, 0)
+			for _, effect := range jobs {
+				delete(rt.postQueued, effect)
+			}
+
+			rt.unlock()
+			for _, effect := range jobs {
+				effect.Run()
+			}
+			rt.mu.Lock()
+		}
+	}
+}
+
+// effectQueue Очередь эффектов по возрастанию высоты, при равной высоте — по порядку постановки
+type effectQueue []*ReactiveEffect
+
+func (q effectQueue) Len() int {
+	return len(q)
+}
+
+func (q effectQueue) Less(i, j int) bool {
+	if q[i].height != q[j].height {
+		return q[i].height < q[j].height
+	}
+	return q[i].queueSeq < q[j].queueSeq
+}
+
+func (q effectQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *effectQueue) Push(x any) {
+	*q = append(*q, x.(*ReactiveEffect))
+}
+
+func (q *effectQueue) Pop() any {
+	old := *q
+	last := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return last
+}
+
+// schedule Отдать эффект планировщику. Паника планировщика не прерывает сброс очереди
+func (rt *Runtime) schedule(effect *ReactiveEffect) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			rt.reportPanic(effect, recovered)
+		}
+	}()
+
+	// Без планировщика эффект перезапускается сразу, как с SyncScheduler
+	if effect.Scheduler == nil {
+		effect.Run()
+		return
+	}
+	effect.Scheduler.Schedule(effect)
+}
+
+// FrameScheduler Копит эффекты и перезапускает их пачкой при вызове Flush,
+// например раз в кадр отрисовки
+type FrameScheduler struct {
+	mu      sync.Mutex
+	pending []*ReactiveEffect
+	seen    map[*ReactiveEffect]bool
+}
+
+// NewFrameScheduler Конструктор
+func NewFrameScheduler() *FrameScheduler {
+	return &FrameScheduler{
+		pending: make([]*ReactiveEffect, 0),
+		seen:    make(map[*ReactiveEffect]bool),
+	}
+}
+
+func (f *FrameScheduler) Schedule(effect *ReactiveEffect) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.seen[effect] {
+		f.seen[effect] = true
+		f.pending = append(f.pending, effect)
+	}
+}
+
+// Pending Количество эффектов, ждущих следующего кадра
+func (f *FrameScheduler) Pending() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.pending)
+}
+
+// Flush Перезапустить все накопленные эффекты. Эффекты могут принадлежать разным средам
+func (f *FrameScheduler) Flush() {
+	f.mu.Lock()
+	effects := f.pending
+	f.pending = make([]*ReactiveEffect, 0)
+	f.seen = make(map[*ReactiveEffect]bool)
+	f.mu.Unlock()
+
+	for _, effect := range effects {
+		effect.Run()
+	}
+}
+
+// Start Вызывать Flush с заданным интервалом. Возвращает функцию остановки
+func (f *FrameScheduler) Start(interval time.Duration) (stop func()) {
+	stopChan := make(chan struct{})
+	var once sync.Once
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				f.Flush()
+			case <-stopChan:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		once.Do(func() {
+			close(stopChan)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package reactivity
+
+import (
+	"testing"
+	"time"
+)
+
+type testReport struct {
+	AllocMB string
+	SysMB   string
+	NumGC   string
+}
+
+// TestBatch проверяем, что эффект перезапускается один раз на транзакцию
+func TestBatch(t *testing.T) {
+	report := &testReport{}
+	runs := 0
+
+	WatchEffect(func() {
+		Track(report, "AllocMB")
+		Track(report, "SysMB")
+		Track(report, "NumGC")
+		runs++
+	})
+
+	Batch(func() {
+		report.AllocMB = "1.00 MB"
+		Trigger(report, "AllocMB")
+		report.SysMB = "2.00 MB"
+		Trigger(report, "SysMB")
+		report.NumGC = "3"
+		Trigger(report, "NumGC")
+
+		if runs != 1 {
+			t.Errorf("Внутри Batch эффект не должен перезапускаться, запусков %d", runs)
+		}
+	})
+
+	if runs != 2 {
+		t.Errorf("Ожидали 2 запуска (начальный и после Batch), получили %d", runs)
+	}
+}
+
+// TestNestedBatch проверяем, что сброс происходит только после внешнего Batch
+func TestNestedBatch(t *testing.T) {
+	report := &testReport{}
+	runs := 0
+
+	WatchEffect(func() {
+		Track(report, "AllocMB")
+		runs++
+	})
+
+	Batch(func() {
+		Batch(func() {
+			Trigger(report, "AllocMB")
+		})
+
+		if runs != 1 {
+			t.Errorf("Вложенный Batch не должен сбрасывать очередь, запусков %d", runs)
+		}
+		Trigger(report, "AllocMB")
+	})
+
+	if runs != 2 {
+		t.Errorf("Ожидали 2 запуска, получили %d", runs)
+	}
+}
+
+// TestQueueScheduler проверяем, что эффект с QueueScheduler запускается после синхронных
+func TestQueueScheduler(t *testing.T) {
+	report := &testReport{}
+	var order []string
+
+	WatchEffect(func() {
+		Track(report, "AllocMB")
+		order = append(order, "queue")
+	}, WithScheduler(QueueScheduler))
+
+	WatchEffect(func() {
+		Track(report, "AllocMB")
+		order = append(order, "sync")
+	})
+
+	order = order[:0]
+	Trigger(report, "AllocMB")
+
+	if len(order) != 2 || order[0] != "sync" || order[1] != "queue" {
+		t.Errorf("Ожидали [sync queue], получили %v", order)
+	}
+}
+
+// TestFrameScheduler проверяем, что эффекты копятся до Flush
+func TestFrameScheduler(t *testing.T) {
+	report := &testReport{}
+	frame := NewFrameScheduler()
+	runs := 0
+
+	WatchEffect(func() {
+		Track(report, "AllocMB")
+		runs++
+	}, WithScheduler(frame))
+
+	Trigger(report, "AllocMB")
+	Trigger(report, "AllocMB")
+
+	if runs != 1 {
+		t.Errorf("До Flush эффект не должен перезапускаться, запусков %d", runs)
+	}
+
+	if frame.Pending() != 1 {
+		t.Errorf("Ожидали 1 эффект в кадре, получили %d", frame.Pending())
+	}
+
+	frame.Flush()
+
+	if runs != 2 {
+		t.Errorf("Ожидали 2 запуска после Flush, получили %d", runs)
+	}
+}
+
+// TestFrameSchedulerStart проверяем периодический сброс кадра
+func TestFrameSchedulerStart(t *testing.T) {
+	report := &testReport{}
+	frame := NewFrameScheduler()
+	done := make(chan struct{}, 1)
+
+	WatchEffect(func() {
+		Track(report, "AllocMB")
+		if report.AllocMB != "" {
+			done <- struct{}{}
+		}
+	}, WithScheduler(frame))
+
+	stop := frame.Start(time.Millisecond)
+	defer stop()
+
+	Batch(func() {
+		report.AllocMB = "1.00 MB"
+		Trigger(report, "AllocMB")
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Эффект должен был перезапуститься на следующем кадре")
+	}
+}
@@ -0,0 +1,178 @@
+package reactivity
+
+// EffectScope Группа эффектов, которые удаляются вместе.
+// Все эффекты и вложенные области, созданные внутри Run, попадают в область
+type EffectScope struct {
+	runtime  *Runtime
+	parent   *EffectScope
+	effects  []*ReactiveEffect
+	scopes   []*EffectScope
+	cleanups []func()
+	active   bool
+}
+
+// NewEffectScope Создать область в среде по умолчанию.
+// Внутри Run другой области новая область становится её дочерней
+func NewEffectScope() *EffectScope {
+	return defaultRuntime.NewEffectScope()
+}
+
+// NewEffectScope Создать область в этой среде
+func (rt *Runtime) NewEffectScope() *EffectScope {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	scope := &EffectScope{
+		runtime:  rt,
+		parent:   rt.currentScopeLocked(),
+		effects:  make([]*ReactiveEffect, 0),
+		scopes:   make([]*EffectScope, 0),
+		cleanups: make([]func(), 0),
+		active:   true,
+	}
+
+	if scope.parent != nil {
+		scope.parent.scopes = append(scope.parent.scopes, scope)
+	}
+
+	return scope
+}
+
+// Active Не остановлена ли область
+func (s *EffectScope) Active() bool {
+	s.runtime.mu.Lock()
+	defer s.runtime.mu.Unlock()
+
+	return s.active
+}
+
+// Run Выполнить fn, собирая созданные в ней эффекты и области. Остановленная область fn не выполняет.
+// Текущая область — состояние владельца цикла, поэтому fn выполняется им (см. owner.go)
+func (s *EffectScope) Run(fn func()) {
+	runOwned(func() {
+		s.run(fn)
+	})
+}
+
+func (s *EffectScope) run(fn func()) {
+	rt := s.runtime
+	rt.mu.Lock()
+	if !s.active {
+		rt.mu.Unlock()
+		return
+	}
+
+	prevScope := rt.activeScope
+	rt.activeScope = s
+	rt.mu.Unlock()
+
+	defer func() {
+		rt.mu.Lock()
+		rt.activeScope = prevScope
+		rt.mu.Unlock()
+	}()
+
+	ownerCall(fn)
+}
+
+// Stop Остановить все эффекты и вложенные области и вызвать обработчики OnScopeDispose
+func (s *EffectScope) Stop() {
+	rt := s.runtime
+	rt.mu.Lock()
+	if !s.active {
+		rt.mu.Unlock()
+		return
+	}
+	s.active = false
+
+	effects := append([]*ReactiveEffect(nil), s.effects...)
+	scopes := append([]*EffectScope(nil), s.scopes...)
+	cleanups := s.cleanups
+	rt.mu.Unlock()
+
+	// Эффекты, области и обработчики останавливаются вне блокировки: OnStop и OnScopeDispose —
+	// пользовательский код
+	for _, effect := range effects {
+		effect.Stop()
+	}
+	for _, scope := range scopes {
+		scope.Stop()
+	}
+	for _, cleanup := range cleanups {
+		cleanup()
+	}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	s.effects = nil
+	s.scopes = nil
+	s.cleanups = nil
+
+	if s.parent != nil {
+		s.parent.removeScope(s)
+	}
+}
+
+// addEffect Запомнить эффект. Эффект, остановленный отдельно, сам уходит из области.
+// Вызывается под блокировкой
+func (s *EffectScope) addEffect(effect *ReactiveEffect) {
+	effect.scope = s
+	s.effects = append(s.effects, effect)
+	effect.OnStop = append(effect.OnStop, func() {
+		s.runtime.mu.Lock()
+		defer s.runtime.mu.Unlock()
+
+		s.removeEffect(effect)
+	})
+}
+
+func (s *EffectScope) removeEffect(effect *ReactiveEffect) {
+	for i, item := range s.effects {
+		if item == effect {
+			s.effects = append(s.effects[:i], s.effects[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *EffectScope) removeScope(scope *EffectScope) {
+	for i, item := range s.scopes {
+		if item == scope {
+			s.scopes = append(s.scopes[:i], s.scopes[i+1:]...)
+			return
+		}
+	}
+}
+
+// OnScopeDispose Зарегистрировать обработчик остановки текущей области среды по умолчанию
+func OnScopeDispose(fn func()) {
+	defaultRuntime.OnScopeDispose(fn)
+}
+
+// OnScopeDispose Зарегистрировать обработчик остановки текущей области.
+// Вне области, но внутри эффекта обработчик попадает в OnStop этого эффекта
+func (rt *Runtime) OnScopeDispose(fn func()) {
+	if !isOwner() {
+		return
+	}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	switch {
+	case rt.activeScope != nil:
+		rt.activeScope.cleanups = append(rt.activeScope.cleanups, fn)
+	case rt.activeEffect != nil:
+		rt.activeEffect.OnStop = append(rt.activeEffect.OnStop, fn)
+	}
+}
+
+// currentScopeLocked Область, в которой работает вызывающая горутина: текущая область среды,
+// если горутина — владелец цикла. Вызывается под блокировкой
+func (rt *Runtime) currentScopeLocked() *EffectScope {
+	if rt.activeScope == nil || !isOwner() {
+		return nil
+	}
+	return rt.activeScope
+}
@@ -0,0 +1,106 @@
+package reactivity
+
+import (
+	"testing"
+)
+
+// TestEffectScopeStop проверяем, что остановка области останавливает все её эффекты
+func TestEffectScopeStop(t *testing.T) {
+	count := NewRef(0)
+	runs := 0
+
+	scope := NewEffectScope()
+	scope.Run(func() {
+		WatchEffect(func() {
+			count.Get()
+			runs++
+		})
+		Watch(count.Get, func(newValue, oldValue int) {
+			runs++
+		}, WatchOptions{})
+	})
+
+	count.Set(1)
+	if runs != 3 {
+		t.Errorf("Ожидали 3 запуска до остановки, получили %d", runs)
+	}
+
+	scope.Stop()
+	count.Set(2)
+
+	if runs != 3 {
+		t.Errorf("После остановки области эффекты не должны запускаться, запусков %d", runs)
+	}
+	if scope.Active() {
+		t.Error("Область должна быть неактивной после Stop")
+	}
+}
+
+// TestNestedEffectScope проверяем остановку вложенных областей вместе с родительской
+func TestNestedEffectScope(t *testing.T) {
+	count := NewRef(0)
+	runs := 0
+	var child *EffectScope
+
+	parent := NewEffectScope()
+	parent.Run(func() {
+		child = NewEffectScope()
+		child.Run(func() {
+			WatchEffect(func() {
+				count.Get()
+				runs++
+			})
+		})
+	})
+
+	parent.Stop()
+	count.Set(1)
+
+	if child.Active() {
+		t.Error("Вложенная область должна остановиться вместе с родительской")
+	}
+	if runs != 1 {
+		t.Errorf("Эффект вложенной области не должен перезапускаться, запусков %d", runs)
+	}
+}
+
+// TestOnScopeDispose проверяем обработчики остановки области и эффекта
+func TestOnScopeDispose(t *testing.T) {
+	var disposed []string
+
+	scope := NewEffectScope()
+	scope.Run(func() {
+		OnScopeDispose(func() {
+			disposed = append(disposed, "scope")
+		})
+	})
+
+	// Вне области обработчик попадает в OnStop эффекта
+	effect := WatchEffect(func() {
+		OnScopeDispose(func() {
+			disposed = append(disposed, "effect")
+		})
+	})
+
+	scope.Stop()
+	effect.Stop()
+
+	if len(disposed) != 2 || disposed[0] != "scope" || disposed[1] != "effect" {
+		t.Errorf("Ожидали [scope effect], получили %v", disposed)
+	}
+}
+
+// TestScopeForgetsStoppedEffect проверяем, что отдельно остановленный эффект уходит из области
+func TestScopeForgetsStoppedEffect(t *testing.T) {
+	var effect *ReactiveEffect
+
+	scope := NewEffectScope()
+	scope.Run(func() {
+		effect = WatchEffect(func() {})
+	})
+
+	effect.Stop()
+	if len(scope.effects) != 0 {
+		t.Errorf("Остановленный эффект должен уйти из области, осталось %d", len(scope.effects))
+	}
+}
@@ -0,0 +1,167 @@
+package reactivity
+
+import (
+	"sync"
+	"time"
+)
+
+// sourceErrKey Ключ, под которым Source отслеживает последнюю ошибку
+const sourceErrKey = "err"
+
+// Source Реактивное значение, которое наполняет внешний поток: канал, тикер или опрос функции.
+// Читается как Ref; Stop отключает поток и освобождает горутины и таймеры
+type Source[T any] struct {
+	*Ref[T]
+
+	err  error
+	done chan struct{}
+
+	mu      sync.Mutex
+	stopped bool
+	stop    chan struct{}
+	// polling Источник опрашивает функцию по таймеру и не держит своей горутины
+	polling bool
+	timer   Timer
+}
+
+func newSource[T any](rt *Runtime, initial T) *Source[T] {
+	return &Source[T]{
+		Ref:  NewRefIn(rt, initial),
+		done: make(chan struct{}),
+		stop: make(chan struct{}),
+	}
+}
+
+// Err Последняя ошибка опроса (nil после успешного). Подписывает активный эффект
+func (s *Source[T]) Err() error {
+	rt := s.runtime
+	rt.mu.Lock()
+	defer rt.unlock()
+
+	rt.trackLocked(s, sourceErrKey)
+	return s.err
+}
+
+func (s *Source[T]) setErr(err error) {
+	rt := s.runtime
+	rt.mu.Lock()
+	defer rt.unlock()
+
+	if s.err == err {
+		return
+	}
+	s.err = err
+	rt.triggerLocked(s, TriggerSet, sourceErrKey)
+}
+
+// Stop Отключить источник. Значение остаётся последним полученным. Повторный вызов ничего не делает
+func (s *Source[T]) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return
+	}
+	s.stopped = true
+	close(s.stop)
+
+	// У опроса нет своей горутины: достаточно отменить следующий вызов
+	if s.polling {
+		if s.timer != nil {
+			s.timer.Stop()
+			s.timer = nil
+		}
+		close(s.done)
+	}
+}
+
+// Done Закрывается, когда источник больше не будет менять значение:
+// после Stop или закрытия канала
+func (s *Source[T]) Done() <-chan struct{} {
+	return s.done
+}
+
+// FromChannel Источник из канала в среде по умолчанию
+func FromChannel[T any](ch <-chan T) *Source[T] {
+	return FromChannelIn(defaultRuntime, ch)
+}
+
+// FromChannelIn Источник, принимающий значение каждого сообщения канала.
+// Останавливается при закрытии канала или вызове Stop
+func FromChannelIn[T any](rt *Runtime, ch <-chan T) *Source[T] {
+	var zero T
+	s := newSource(rt, zero)
+
+	go func() {
+		defer close(s.done)
+
+		for {
+			select {
+			case value, ok := <-ch:
+				if !ok {
+					return
+				}
+				s.Set(value)
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+
+	return s
+}
+
+// FromTicker Источник из тикера в среде по умолчанию
+func FromTicker[T any](interval time.Duration, fn func() T) *Source[T] {
+	return FromTickerIn(defaultRuntime, interval, fn)
+}
+
+// FromTickerIn Источник, значение которого вычисляется fn сразу и затем раз в interval.
+// Время отсчитывают часы среды
+func FromTickerIn[T any](rt *Runtime, interval time.Duration, fn func() T) *Source[T] {
+	return FromFuncIn(rt, interval, func() (T, error) {
+		return fn(), nil
+	})
+}
+
+// FromFunc Опрос функции в среде по умолчанию
+func FromFunc[T any](interval time.Duration, fn func() (T, error)) *Source[T] {
+	return FromFuncIn(defaultRuntime, interval, fn)
+}
+
+// FromFuncIn Источник, опрашивающий fn сразу и затем раз в interval.
+// При ошибке значение не меняется, а ошибка доступна через Err.
+// Следующий опрос планируется после завершения предыдущего, поэтому вызовы fn не пересекаются
+func FromFuncIn[T any](rt *Runtime, interval time.Duration, fn func() (T, error)) *Source[T] {
+	var zero T
+	s := newSource(rt, zero)
+	s.polling = true
+	clock := rt.Clock()
+
+	var poll func()
+	poll = func() {
+		value, err := fn()
+
+		s.mu.Lock()
+		stopped := s.stopped
+		s.mu.Unlock()
+		if stopped {
+			return
+		}
+
+		if err == nil {
+			s.Set(value)
+		}
+		s.setErr(err)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		// Эффекты, разбуженные значением, могли остановить источник
+		if !s.stopped {
+			s.timer = clock.AfterFunc(interval, poll)
+		}
+	}
+	poll()
+
+	return s
+}
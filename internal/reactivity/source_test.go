@@ -0,0 +1,115 @@
+package reactivity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestFromChannel проверяем, что сообщения канала будят эффекты, а закрытие канала завершает источник
+func TestFromChannel(t *testing.T) {
+	rt := NewRuntime()
+	ch := make(chan string)
+	source := FromChannelIn(rt, ch)
+
+	seen := make(chan string, 4)
+	rt.WatchEffect(func() {
+		seen <- source.Get()
+	})
+	<-seen
+
+	ch <- "1.00 MB"
+	if got := waitString(t, seen); got != "1.00 MB" {
+		t.Errorf("Ожидали 1.00 MB, получили %s", got)
+	}
+
+	close(ch)
+	select {
+	case <-source.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Закрытие канала должно завершать источник")
+	}
+}
+
+// TestFromChannelStop проверяем, что Stop завершает горутину источника
+func TestFromChannelStop(t *testing.T) {
+	source := FromChannelIn(NewRuntime(), make(chan int))
+	source.Stop()
+	source.Stop()
+
+	select {
+	case <-source.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop должен завершать источник")
+	}
+}
+
+// TestFromTicker проверяем опрос сразу при создании и по каждому тику часов среды
+func TestFromTicker(t *testing.T) {
+	rt := NewRuntime()
+	clock := NewFakeClock(time.Unix(0, 0))
+	rt.SetClock(clock)
+
+	ticks := 0
+	source := FromTickerIn(rt, time.Second, func() int {
+		ticks++
+		return ticks
+	})
+
+	var rendered []int
+	rt.WatchEffect(func() {
+		rendered = append(rendered, source.Get())
+	})
+
+	clock.Advance(time.Second)
+	clock.Advance(time.Second)
+	source.Stop()
+	clock.Advance(time.Second)
+
+	want := []int{1, 2, 3}
+	if len(rendered) != len(want) {
+		t.Fatalf("Ожидали %v, получили %v", want, rendered)
+	}
+	for i := range want {
+		if rendered[i] != want[i] {
+			t.Errorf("Ожидали %v, получили %v", want, rendered)
+			break
+		}
+	}
+	if clock.Pending() != 0 {
+		t.Errorf("После Stop не должно остаться таймеров, осталось %d", clock.Pending())
+	}
+}
+
+// TestFromFuncError проверяем, что ошибка опроса не меняет значение и доступна через Err
+func TestFromFuncError(t *testing.T) {
+	rt := NewRuntime()
+	clock := NewFakeClock(time.Unix(0, 0))
+	rt.SetClock(clock)
+
+	errRead := errors.New("нет доступа к /proc")
+	fail := false
+	source := FromFuncIn(rt, time.Second, func() (string, error) {
+		if fail {
+			return "", errRead
+		}
+		return "ok", nil
+	})
+	defer source.Stop()
+
+	fail = true
+	clock.Advance(time.Second)
+
+	if source.Get() != "ok" {
+		t.Errorf("При ошибке значение не должно меняться, получили %q", source.Get())
+	}
+	if !errors.Is(source.Err(), errRead) {
+		t.Errorf("Ожидали ошибку опроса, получили %v", source.Err())
+	}
+
+	fail = false
+	clock.Advance(time.Second)
+	if source.Err() != nil {
+		t.Errorf("Успешный опрос должен сбрасывать ошибку, получили %v", source.Err())
+	}
+}
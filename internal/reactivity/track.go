@@ -1,56 +1,175 @@
 package reactivity
 
 import (
+	"fmt"
 	"reflect"
+	"runtime"
+	"weak"
 )
 
-func getObjectID(obj interface{}) uintptr {
-	return reflect.ValueOf(obj).Pointer()
+// targetKey Слабая ссылка на цель. Цель должна быть ссылочной (указатель, map, slice, chan):
+// пока она жива, ключ стабилен, а после сборки новая цель по тому же адресу получит другой ключ.
+// Для nil-цели возвращает ok == false
+func targetKey(target interface{}) (key weak.Pointer[byte], ptr *byte, ok bool) {
+	value := reflect.ValueOf(target)
+
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.UnsafePointer:
+	default:
+		panic(fmt.Sprintf("reactivity: цель должна быть ссылочной, получено %T", target))
+	}
+
+	ptr = (*byte)(value.UnsafePointer())
+	if ptr == nil {
+		return key, nil, false
+	}
+
+	return weak.Make(ptr), ptr, true
 }
 
-func Track(target interface{}, key string) {
-	if activeEffect == nil {
+// Track Подписать активный эффект на ключ цели
+func (rt *Runtime) Track(target interface{}, key string) {
+	rt.mu.Lock()
+	defer rt.unlock()
+
+	rt.trackLocked(target, key)
+}
+
+func (rt *Runtime) trackLocked(target interface{}, key string) {
+	if !rt.shouldTrack {
+		return
+	}
+	effect := rt.currentEffectLocked()
+	if effect == nil {
 		return
 	}
 
-	objectID := getObjectID(target)
+	objectID, ptr, ok := targetKey(target)
+	if !ok {
+		return
+	}
 
-	depsMap, exists := targetMap[objectID]
+	depsMap, exists := rt.targetMap[objectID]
 	if !exists {
 		depsMap = make(map[string]*Dep)
-		targetMap[objectID] = depsMap
+		rt.targetMap[objectID] = depsMap
+	}
+
+	// Когда цель соберут, удаляем её из карты вместе со всеми зависимостями
+	if !rt.cleanups[objectID] {
+		rt.cleanups[objectID] = true
+		runtime.AddCleanup(ptr, rt.forgetTarget, objectID)
 	}
 
 	dep, exists := depsMap[key]
 	if !exists {
-		dep = NewDep()
+		dep = rt.newDepLocked()
+		dep.target = objectID
+		dep.key = key
+		dep.targetType = fmt.Sprintf("%T", target)
 		depsMap[key] = dep
 	}
 
-	dep.track()
+	dep.trackEffect(effect)
+}
+
+// TriggerType Вид изменения, вызвавшего срабатывание
+type TriggerType string
+
+const (
+	TriggerSet    TriggerType = "set"    // изменено существующее значение
+	TriggerAdd    TriggerType = "add"    // добавлен ключ или элемент
+	TriggerDelete TriggerType = "delete" // удалён ключ или элемент
+)
+
+// Trigger Перезапустить эффекты, подписанные на ключ цели
+func (rt *Runtime) Trigger(target interface{}, key string) {
+	rt.mu.Lock()
+	defer rt.unlock()
+
+	rt.triggerLocked(target, TriggerSet, key)
 }
 
-func Trigger(target interface{}, key string) {
-	objectID := getObjectID(target)
+// triggerLocked Оповестить подписчиков нескольких ключей цели. Эффекты ставятся в очередь
+// и запускаются одной транзакцией после снятия блокировки
+func (rt *Runtime) triggerLocked(target interface{}, triggerType TriggerType, keys ...string) {
+	objectID, _, ok := targetKey(target)
+	if !ok {
+		return
+	}
 
-	depsMap, exists := targetMap[objectID]
+	depsMap, exists := rt.targetMap[objectID]
 	if !exists {
 		return
 	}
 
-	dep, exists := depsMap[key]
+	for _, key := range keys {
+		if dep, exists := depsMap[key]; exists {
+			dep.notify(triggerType)
+		}
+	}
+}
+
+// Untrack Забыть цель: отписать от неё все эффекты и удалить её зависимости
+func (rt *Runtime) Untrack(target interface{}) {
+	objectID, _, ok := targetKey(target)
+	if !ok {
+		return
+	}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	rt.dropTarget(objectID)
+}
+
+// forgetTarget Удалить собранную цель из карты. Вызывается из горутины очистки runtime
+func (rt *Runtime) forgetTarget(objectID weak.Pointer[byte]) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	delete(rt.cleanups, objectID)
+	rt.dropTarget(objectID)
+}
+
+func (rt *Runtime) dropTarget(objectID weak.Pointer[byte]) {
+	depsMap, exists := rt.targetMap[objectID]
 	if !exists {
 		return
 	}
 
-	dep.trigger()
+	for _, dep := range depsMap {
+		dep.detach()
+	}
+	delete(rt.targetMap, objectID)
+}
+
+// releaseDep Удалить зависимость из карты, если у неё не осталось подписчиков
+func (rt *Runtime) releaseDep(dep *Dep) {
+	if len(dep.Subscribers) > 0 {
+		return
+	}
+
+	depsMap, exists := rt.targetMap[dep.target]
+	if !exists || depsMap[dep.key] != dep {
+		return
+	}
+
+	delete(depsMap, dep.key)
+	if len(depsMap) == 0 {
+		delete(rt.targetMap, dep.target)
+	}
 }
 
-func GetTargetMapStats() (targets int, totalDeps int, totalEffects int) {
-	targets = len(targetMap)
+// GetTargetMapStats Количество целей, зависимостей и подписанных эффектов
+func (rt *Runtime) GetTargetMapStats() (targets int, totalDeps int, totalEffects int) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	targets = len(rt.targetMap)
 	effectsMap := make(map[int]bool)
-	
-	for _, depsMap := range targetMap {
+
+	for _, depsMap := range rt.targetMap {
 		totalDeps += len(depsMap)
 		for _, dep := range depsMap {
 			for _, effect := range dep.Subscribers {
@@ -58,7 +177,7 @@ func GetTargetMapStats() (targets int, totalDeps int, totalEffects int) {
 			}
 		}
 	}
-	
+
 	totalEffects = len(effectsMap)
 	return
 }
@@ -0,0 +1,95 @@
+package reactivity
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// TestUntrack проверяем, что после Untrack эффекты больше не перезапускаются
+func TestUntrack(t *testing.T) {
+	rt := NewRuntime()
+	counter := &testCounter{}
+	runs := 0
+
+	effect := rt.WatchEffect(func() {
+		rt.Track(counter, "Value")
+		runs++
+	})
+
+	rt.Untrack(counter)
+	rt.Trigger(counter, "Value")
+
+	if runs != 1 {
+		t.Errorf("После Untrack эффект не должен перезапускаться, запусков %d", runs)
+	}
+	if len(effect.Deps) != 0 {
+		t.Errorf("У эффекта не должно остаться зависимостей, осталось %d", len(effect.Deps))
+	}
+	if targets, deps, _ := rt.GetTargetMapStats(); targets != 0 || deps != 0 {
+		t.Errorf("Карта должна быть пустой, целей %d, зависимостей %d", targets, deps)
+	}
+}
+
+// TestStopReleasesDeps проверяем, что зависимости без подписчиков удаляются при остановке эффекта
+func TestStopReleasesDeps(t *testing.T) {
+	rt := NewRuntime()
+	report := &testReport{}
+
+	first := rt.WatchEffect(func() {
+		rt.Track(report, "AllocMB")
+		rt.Track(report, "SysMB")
+	})
+	second := rt.WatchEffect(func() {
+		rt.Track(report, "AllocMB")
+	})
+
+	first.Stop()
+	if targets, deps, _ := rt.GetTargetMapStats(); targets != 1 || deps != 1 {
+		t.Errorf("Ожидали 1 цель и 1 зависимость, получили %d и %d", targets, deps)
+	}
+
+	second.Stop()
+	if targets, deps, _ := rt.GetTargetMapStats(); targets != 0 || deps != 0 {
+		t.Errorf("Карта должна быть пустой, целей %d, зависимостей %d", targets, deps)
+	}
+}
+
+// TestCollectedTargetIsForgotten проверяем, что собранная цель удаляется из карты
+func TestCollectedTargetIsForgotten(t *testing.T) {
+	rt := NewRuntime()
+	holder := &struct{ counter *testCounter }{counter: &testCounter{}}
+
+	rt.WatchEffect(func() {
+		if holder.counter != nil {
+			rt.Track(holder.counter, "Value")
+		}
+	})
+
+	if targets, _, _ := rt.GetTargetMapStats(); targets != 1 {
+		t.Fatalf("Ожидали 1 цель, получили %d", targets)
+	}
+
+	holder.counter = nil
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		runtime.GC()
+		if targets, _, _ := rt.GetTargetMapStats(); targets == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("Собранная цель должна быть удалена из карты")
+}
+
+// TestNilTarget проверяем, что nil-цель игнорируется
+func TestNilTarget(t *testing.T) {
+	var counter *testCounter
+
+	WatchEffect(func() {
+		Track(counter, "Value")
+	})
+	Trigger(counter, "Value")
+	Untrack(counter)
+}
@@ -0,0 +1,66 @@
+package reactivity
+
+// PauseTracking Приостановить отслеживание: чтения не подписывают активный эффект.
+// Вне владельца цикла (см. owner.go) чтения и так никого не подписывают, поэтому
+// PauseTracking и парный ResumeTracking там ничего не делают
+func (rt *Runtime) PauseTracking() {
+	if !isOwner() {
+		return
+	}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	rt.trackStack = append(rt.trackStack, rt.shouldTrack)
+	rt.shouldTrack = false
+}
+
+// ResumeTracking Вернуть отслеживание в состояние до парного PauseTracking
+func (rt *Runtime) ResumeTracking() {
+	if !isOwner() {
+		return
+	}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if len(rt.trackStack) == 0 {
+		panic("reactivity: ResumeTracking без парного PauseTracking")
+	}
+
+	last := len(rt.trackStack) - 1
+	rt.shouldTrack = rt.trackStack[last]
+	rt.trackStack = rt.trackStack[:last]
+}
+
+// Untracked Выполнить fn без отслеживания зависимостей
+func (rt *Runtime) Untracked(fn func()) {
+	rt.PauseTracking()
+	defer rt.ResumeTracking()
+
+	fn()
+}
+
+// PauseTracking Приостановить отслеживание в среде по умолчанию
+func PauseTracking() {
+	defaultRuntime.PauseTracking()
+}
+
+// ResumeTracking Вернуть отслеживание в среде по умолчанию
+func ResumeTracking() {
+	defaultRuntime.ResumeTracking()
+}
+
+// Untracked Выполнить fn без отслеживания в среде по умолчанию
+func Untracked(fn func()) {
+	defaultRuntime.Untracked(fn)
+}
+
+// UntrackedValue Прочитать значение без подписки, например UntrackedValue(config.Get)
+func UntrackedValue[T any](read func() T) T {
+	var value T
+	Untracked(func() {
+		value = read()
+	})
+	return value
+}
@@ -0,0 +1,97 @@
+package reactivity
+
+import (
+	"testing"
+)
+
+// TestUntracked проверяем чтение без подписки внутри эффекта
+func TestUntracked(t *testing.T) {
+	value := NewRef(1)
+	config := NewRef("dark")
+	runs := 0
+
+	WatchEffect(func() {
+		value.Get()
+		Untracked(func() {
+			config.Get()
+		})
+		runs++
+	})
+
+	config.Set("light")
+	if runs != 1 {
+		t.Errorf("Изменение неотслеживаемого значения не должно перезапускать эффект, запусков %d", runs)
+	}
+
+	value.Set(2)
+	if runs != 2 {
+		t.Errorf("Изменение отслеживаемого значения должно перезапускать эффект, запусков %d", runs)
+	}
+}
+
+// TestPauseResumeTracking проверяем вложенные паузы
+func TestPauseResumeTracking(t *testing.T) {
+	first := NewRef(0)
+	second := NewRef(0)
+	third := NewRef(0)
+	runs := 0
+
+	WatchEffect(func() {
+		PauseTracking()
+		first.Get()
+		PauseTracking()
+		second.Get()
+		ResumeTracking()
+		second.Get()
+		ResumeTracking()
+		third.Get()
+		runs++
+	})
+
+	first.Set(1)
+	second.Set(1)
+	if runs != 1 {
+		t.Errorf("Чтения во время паузы не должны подписывать эффект, запусков %d", runs)
+	}
+
+	third.Set(1)
+	if runs != 2 {
+		t.Errorf("После ResumeTracking отслеживание должно вернуться, запусков %d", runs)
+	}
+}
+
+// TestEffectInsideUntracked проверяем, что эффект, созданный внутри Untracked, отслеживает свои чтения
+func TestEffectInsideUntracked(t *testing.T) {
+	value := NewRef(0)
+	runs := 0
+
+	Untracked(func() {
+		WatchEffect(func() {
+			value.Get()
+			runs++
+		})
+	})
+
+	value.Set(1)
+	if runs != 2 {
+		t.Errorf("Ожидали 2 запуска, получили %d", runs)
+	}
+}
+
+// TestUntrackedValue проверяем чтение значения без подписки
+func TestUntrackedValue(t *testing.T) {
+	config := NewRef(10)
+	runs := 0
+
+	WatchEffect(func() {
+		if UntrackedValue(config.Get) != 10 {
+			t.Error("Ожидали 10")
+		}
+		runs++
+	})
+
+	config.Set(20)
+	if runs != 1 {
+		t.Errorf("Ожидали 1 запуск, получили %d", runs)
+	}
+}
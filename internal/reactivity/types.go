@@ -1,17 +1,14 @@
 package reactivity
 
+import (
+	"weak"
+)
+
 // Общие типы и переменные
 type EffectFunc func()
 
-// Глобальное состояние
-var (
-	activeEffect *ReactiveEffect
-	shouldTrack  = true
-	effectIdSeq  = 0
-	depIdSeq     = 0
-	effectStack  []*ReactiveEffect
-	targetMap    = make(TargetMap)
-)
+// Карта зависимостей. Цели хранятся по слабым ссылкам и не удерживаются от сборки мусора
+type TargetMap map[weak.Pointer[byte]]map[string]*Dep
 
-// Карта зависимостей
-type TargetMap map[uintptr]map[string]*Dep
+// defaultRuntime Среда, которой пользуются свободные функции пакета (Track, Trigger, WatchEffect, ...)
+var defaultRuntime = NewRuntime()
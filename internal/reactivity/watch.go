@@ -0,0 +1,87 @@
+package reactivity
+
+import (
+	"reflect"
+)
+
+// WatchOptions Настройки Watch
+type WatchOptions struct {
+	// Immediate вызвать callback сразу с текущим значением (oldValue — нулевое значение T)
+	Immediate bool
+	// Deep сравнивать значения через reflect.DeepEqual с глубокой копией прежнего значения,
+	// чтобы замечать изменения внутри указателей, срезов и map
+	Deep bool
+	// Once остановить наблюдение после первого вызова callback
+	Once bool
+}
+
+// WatchStopHandle Функция остановки наблюдения
+type WatchStopHandle func()
+
+// Watch Следить за источником в среде по умолчанию и получать прежнее и новое значения
+func Watch[T any](source func() T, callback func(newValue, oldValue T), opts WatchOptions) WatchStopHandle {
+	return WatchIn(defaultRuntime, source, callback, opts)
+}
+
+// WatchIn Следить за источником в заданной среде.
+// source отслеживается как эффект; callback вызывается без отслеживания
+func WatchIn[T any](rt *Runtime, source func() T, callback func(newValue, oldValue T), opts WatchOptions) WatchStopHandle {
+	var oldValue T
+	initialized := false
+
+	var effect *ReactiveEffect
+	effect = rt.NewReactiveEffect(func() {
+		newValue := source()
+
+		if !initialized {
+			initialized = true
+			oldValue = watchSnapshot(newValue, opts.Deep)
+			if opts.Immediate {
+				var zero T
+				rt.runCallback(effect, func() { callback(newValue, zero) }, opts.Once)
+			}
+			return
+		}
+
+		if !watchChanged(oldValue, newValue, opts.Deep) {
+			return
+		}
+
+		prevValue := oldValue
+		oldValue = watchSnapshot(newValue, opts.Deep)
+		rt.runCallback(effect, func() { callback(newValue, prevValue) }, opts.Once)
+	})
+
+	effect.Run()
+	return effect.Stop
+}
+
+// runCallback Вызвать callback наблюдателя вне отслеживания и остановить эффект при Once
+func (rt *Runtime) runCallback(effect *ReactiveEffect, callback func(), once bool) {
+	rt.Untracked(callback)
+
+	if once {
+		effect.Stop()
+	}
+}
+
+func watchSnapshot[T any](value T, deep bool) T {
+	if deep {
+		return deepCopy(value)
+	}
+	return value
+}
+
+// watchChanged Изменилось ли значение. Без Deep несравнимые типы (срезы, map) считаются изменёнными всегда
+func watchChanged[T any](oldValue, newValue T, deep bool) bool {
+	if deep {
+		return !reflect.DeepEqual(oldValue, newValue)
+	}
+
+	oldReflect := reflect.ValueOf(&oldValue).Elem()
+	newReflect := reflect.ValueOf(&newValue).Elem()
+	if !oldReflect.Comparable() || !newReflect.Comparable() {
+		return true
+	}
+	return !oldReflect.Equal(newReflect)
+}
@@ -0,0 +1,170 @@
+package reactivity
+
+import (
+	"testing"
+)
+
+// TestWatch проверяем передачу прежнего и нового значений
+func TestWatch(t *testing.T) {
+	count := NewRef(1)
+	var calls [][2]int
+
+	stop := Watch(count.Get, func(newValue, oldValue int) {
+		calls = append(calls, [2]int{newValue, oldValue})
+	}, WatchOptions{})
+
+	if len(calls) != 0 {
+		t.Errorf("Без Immediate callback не должен вызываться сразу, вызовов %d", len(calls))
+	}
+
+	count.Set(2)
+	count.Set(5)
+
+	if len(calls) != 2 || calls[0] != [2]int{2, 1} || calls[1] != [2]int{5, 2} {
+		t.Errorf("Ожидали [[2 1] [5 2]], получили %v", calls)
+	}
+
+	stop()
+	count.Set(7)
+	if len(calls) != 2 {
+		t.Errorf("После остановки callback не должен вызываться, вызовов %d", len(calls))
+	}
+}
+
+// TestWatchImmediate проверяем немедленный вызов
+func TestWatchImmediate(t *testing.T) {
+	name := NewRef("Вася")
+	var got []string
+
+	Watch(name.Get, func(newValue, oldValue string) {
+		got = append(got, oldValue+"->"+newValue)
+	}, WatchOptions{Immediate: true})
+
+	name.Set("Петя")
+
+	if len(got) != 2 || got[0] != "->Вася" || got[1] != "Вася->Петя" {
+		t.Errorf("Ожидали [->Вася Вася->Петя], получили %v", got)
+	}
+}
+
+// TestWatchOnce проверяем остановку после первого вызова
+func TestWatchOnce(t *testing.T) {
+	count := NewRef(0)
+	calls := 0
+
+	Watch(count.Get, func(newValue, oldValue int) {
+		calls++
+	}, WatchOptions{Once: true})
+
+	count.Set(1)
+	count.Set(2)
+
+	if calls != 1 {
+		t.Errorf("Ожидали 1 вызов, получили %d", calls)
+	}
+}
+
+// TestWatchDeep проверяем сравнение вложенных значений
+func TestWatchDeep(t *testing.T) {
+	dashboard := &testDashboard{labels: []string{"a"}}
+	rt := NewRuntime()
+	source := func() *testDashboard {
+		rt.Track(dashboard, "NumGC")
+		return dashboard
+	}
+
+	shallowCalls, deepCalls := 0, 0
+	WatchIn(rt, source, func(newValue, oldValue *testDashboard) {
+		shallowCalls++
+	}, WatchOptions{})
+
+	var deepOld, deepNew int
+	WatchIn(rt, source, func(newValue, oldValue *testDashboard) {
+		deepCalls++
+		deepOld, deepNew = oldValue.NumGC, newValue.NumGC
+	}, WatchOptions{Deep: true})
+
+	dashboard.NumGC = 3
+	rt.Trigger(dashboard, "NumGC")
+
+	// Тот же указатель: без Deep изменение внутри не видно
+	if shallowCalls != 0 {
+		t.Errorf("Без Deep callback не должен вызываться, вызовов %d", shallowCalls)
+	}
+	if deepCalls != 1 || deepOld != 0 || deepNew != 3 {
+		t.Errorf("Ожидали 1 вызов 0->3, получили %d вызовов %d->%d", deepCalls, deepOld, deepNew)
+	}
+
+	// Значение не изменилось: Deep не вызывает callback
+	rt.Trigger(dashboard, "NumGC")
+	if deepCalls != 1 {
+		t.Errorf("Без изменений Deep callback не должен вызываться, вызовов %d", deepCalls)
+	}
+}
+
+// testTreeNode Узел дерева со ссылкой на родителя: значение с циклом
+type testTreeNode struct {
+	Name     string
+	Parent   *testTreeNode
+	Children []*testTreeNode
+}
+
+// TestWatchDeepCycle проверяем Deep на значении с циклом
+func TestWatchDeepCycle(t *testing.T) {
+	root := &testTreeNode{Name: "root"}
+	root.Children = []*testTreeNode{{Name: "child", Parent: root}}
+	rt := NewRuntime()
+	source := func() *testTreeNode {
+		rt.Track(root, "Children")
+		return root
+	}
+
+	var got []string
+	WatchIn(rt, source, func(newValue, oldValue *testTreeNode) {
+		got = append(got, oldValue.Children[0].Name+"->"+newValue.Children[0].Name)
+	}, WatchOptions{Deep: true})
+
+	root.Children[0].Name = "renamed"
+	rt.Trigger(root, "Children")
+
+	if len(got) != 1 || got[0] != "child->renamed" {
+		t.Errorf("Ожидали [child->renamed], получили %v", got)
+	}
+}
+
+// TestWatchCallbackUntracked проверяем, что чтения внутри callback не подписывают наблюдателя
+func TestWatchCallbackUntracked(t *testing.T) {
+	count := NewRef(0)
+	other := NewRef(0)
+	calls := 0
+
+	Watch(count.Get, func(newValue, oldValue int) {
+		other.Get()
+		calls++
+	}, WatchOptions{})
+
+	count.Set(1)
+	other.Set(1)
+
+	if calls != 1 {
+		t.Errorf("Изменение значения, прочитанного в callback, не должно вызывать его, вызовов %d", calls)
+	}
+}
+
+// TestDeepCopy проверяем независимость копии
+func TestDeepCopy(t *testing.T) {
+	original := map[string][]int{"a": {1, 2}}
+	copied := deepCopy(original)
+	original["a"][0] = 100
+
+	if copied["a"][0] != 1 {
+		t.Error("Копия не должна зависеть от оригинала")
+	}
+
+	root := &testTreeNode{Name: "root"}
+	root.Children = []*testTreeNode{{Name: "child", Parent: root}}
+	tree := deepCopy(root)
+	if tree == root || tree.Children[0].Parent != tree {
+		t.Error("Цикл в копии должен замыкаться на копию, а не на оригинал")
+	}
+}
@@ -42,15 +42,6 @@ func NewMemoryMonitorReport() *MemoryMonitorReport {
 	}
 }
 
-func createWatcher(entity *proxy.ReactiveProxy, fieldName string) {
-	entity.Watch(fieldName, "Get", func(fieldName string, oldValue, newValue interface{}) {
-		reactivity.Track(entity.Original(), "Get")
-	})
-	entity.Watch(fieldName, "Set", func(fieldName string, oldValue, newValue interface{}) {
-		reactivity.Trigger(entity.Original(), "Get")
-	})
-}
-
 func main() {
 	mm := monitor.NewMemoryMonitor(1000, 100)
 	mm.Start()
@@ -184,7 +175,6 @@ func main() {
 
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	stopChan := make(chan struct{})
 
 	// Навешиваю прокси на отчет о потреблении памяти
 	report := NewMemoryMonitorReport()
@@ -193,12 +183,11 @@ func main() {
 	// Создаем курсор для обеспечения точечного ререндера
 	cursor := terminal.NewCursorManager()
 
-	// Настраиваем наблюдатели
-	createWatcher(proxyMemoryMonitorReport, "AllocMB")
-	createWatcher(proxyMemoryMonitorReport, "SysMB")
-	createWatcher(proxyMemoryMonitorReport, "NumGC")
-	createWatcher(proxyMemoryMonitorReport, "Goroutines")
-	createWatcher(proxyMemoryMonitorReport, "HeapObjects")
+	// Ошибки эффектов выводим строкой под отчетом, не ломая разметку терминала
+	reactivity.SetErrorHandler(func(err *reactivity.EffectError) {
+		cursor.ClearLine(21)
+		cursor.WriteAt(1, 21, fmt.Sprintf("[ERROR] %v", err))
+	})
 
 	posMaps := map[string]renderer.Position{
 		"AllocMB":     {X: 23, Y: 4},
@@ -208,68 +197,81 @@ func main() {
 		"HeapObjects": {X: 23, Y: 12},
 	}
 
-	reactivity.WatchEffect(func() {
-		cursor.ShowCursor()
-		for _, fieldName := range report.fieldNamesMemoryMonitor() {
-			value := proxyMemoryMonitorReport.Get(fieldName)
+	// Дашборд только читает отчет: отдаем ему представление без права записи
+	dashboardState := proxyMemoryMonitorReport.Readonly()
+
+	// Каждое поле рисует свой эффект: Proxy подписывает его только на это поле,
+	// поэтому перерисовываются лишь изменившиеся ячейки
+	for _, fieldName := range report.fieldNamesMemoryMonitor() {
+		fieldName := fieldName
+		reactivity.WatchEffect(func() {
+			value := dashboardState.Get(fieldName)
 			pos := posMaps[fieldName]
+			cursor.ShowCursor()
 			cursor.WriteAt(pos.X, pos.Y, fmt.Sprintf("%v", value))
-		}
-		cursor.HideCursor()
-	})
+			cursor.HideCursor()
+		}, reactivity.WithName("dashboard:"+fieldName))
+	}
 
-	values := make(map[string]interface{}, 5)
-	count := 0
+	// Отчет о памяти снимаем раз в секунду: источник сам опрашивает монитор и будит эффекты
 	const SECONDS = 1
-	startingHeapSize := 0
-
-	go func() {
-		ticker := time.NewTicker(SECONDS * time.Second)
-		defer ticker.Stop()
+	started := time.Now()
+	currentReport := reactivity.FromTicker(SECONDS*time.Second, func() MemoryMonitorReport {
+		a, s, n, g, h := mm.PrintCurrent()
+		return MemoryMonitorReport{AllocMB: a, SysMB: s, NumGC: n, Goroutines: g, HeapObjects: h}
+	})
+	secondsPassed := reactivity.FromTicker(SECONDS*time.Second, func() int {
+		return int(time.Since(started).Seconds())
+	})
 
-		for {
-			select {
-			case <-ticker.C:
-				a, s, n, g, h := mm.PrintCurrent()
-				values["AllocMB"] = a
-				values["SysMB"] = s
-				values["NumGC"] = n
-				values["Goroutines"] = g
-				values["HeapObjects"] = h
-				for key, value := range values {
-					proxyMemoryMonitorReport.Set(key, value)
-				}
+	reactivity.WatchEffect(func() {
+		current := currentReport.Get()
+		values := map[string]interface{}{
+			"AllocMB":     current.AllocMB,
+			"SysMB":       current.SysMB,
+			"NumGC":       current.NumGC,
+			"Goroutines":  current.Goroutines,
+			"HeapObjects": current.HeapObjects,
+		}
+		// Одна транзакция на тик: каждая ячейка дашборда перерисуется не больше одного раза
+		reactivity.Batch(func() {
+			for key, value := range values {
+				proxyMemoryMonitorReport.Set(key, value)
+			}
+		})
+	}, reactivity.WithName("report"))
 
-				targets, deps, effects := reactivity.GetTargetMapStats()
-				cursor.WriteAt(1, 15, fmt.Sprintf("[DEBUG] Reactivity: %d targets, %d deps, %d effects", 
-				targets, deps, effects))
+	startingHeapSize := 0
+	reactivity.WatchEffect(func() {
+		current := currentReport.Get()
 
-				count += SECONDS
-				cursor.WriteAt(1, 17, fmt.Sprintf("[TIME] Seconds passed: %d", count))
+		targets, deps, effects := reactivity.GetTargetMapStats()
+		cursor.WriteAt(1, 15, fmt.Sprintf("[DEBUG] Reactivity: %d targets, %d deps, %d effects", 
+		targets, deps, effects))
 
-				currentHeapSize := 0
-				num, err := strconv.ParseInt(h, 10, 64)
-				if err == nil {  // ← Если НЕТ ошибки
-					currentHeapSize = int(num)  // ← Приводим int64 к int
-				}
+		currentHeapSize := 0
+		num, err := strconv.ParseInt(current.HeapObjects, 10, 64)
+		if err == nil {  // ← Если НЕТ ошибки
+			currentHeapSize = int(num)  // ← Приводим int64 к int
+		}
 
-				if startingHeapSize == 0 {
-					startingHeapSize = currentHeapSize
-				}
+		if startingHeapSize == 0 {
+			startingHeapSize = currentHeapSize
+		}
 
-				deviationHeapSize := currentHeapSize - startingHeapSize
-				cursor.ClearLine(19)
-				cursor.WriteAt(1, 19, fmt.Sprintf("[HEAP SIZE] Deviation: (start: %d, current: %d) %d", 
-					startingHeapSize, currentHeapSize, deviationHeapSize))
+		deviationHeapSize := currentHeapSize - startingHeapSize
+		cursor.ClearLine(19)
+		cursor.WriteAt(1, 19, fmt.Sprintf("[HEAP SIZE] Deviation: (start: %d, current: %d) %d", 
+			startingHeapSize, currentHeapSize, deviationHeapSize))
+	}, reactivity.WithName("heap"))
 
-			case <-stopChan:
-				return
-			}
-		}
-	}()
+	reactivity.WatchEffect(func() {
+		cursor.WriteAt(1, 17, fmt.Sprintf("[TIME] Seconds passed: %d", secondsPassed.Get()))
+	}, reactivity.WithName("timer"))
 
 	<-sigChan
-	close(stopChan)
+	currentReport.Stop()
+	secondsPassed.Stop()
 	terminal.Clear()
 	fmt.Println("Программа завершена.")
 }